@@ -0,0 +1,22 @@
+ephemeral "terracurl_request" "vault_token" {
+  method         = "POST"
+  name           = "vault-token"
+  response_codes = ["200"]
+  url            = "https://vault.example.com/v1/auth/approle/login"
+  request_body   = jsonencode({ role_id = var.role_id, secret_id = var.secret_id })
+
+  skip_renew           = false
+  renew_interval       = 300
+  renew_url            = "https://vault.example.com/v1/auth/token/renew-self"
+  renew_response_codes = ["200"]
+  renew_method         = "POST"
+
+  # Renew once 80% of the lease has elapsed, using renew_interval if the
+  # response has no lease_duration.
+  renew_at_from {
+    ttl_path              = "auth.lease_duration"
+    safety_margin_percent = 20
+  }
+
+  skip_close = true
+}
@@ -0,0 +1,16 @@
+resource "terracurl_request" "token" {
+  name           = "token"
+  url            = "https://auth.example.com/oauth/token"
+  method         = "POST"
+  response_codes = ["200"]
+
+  form_body = {
+    grant_type    = "client_credentials"
+    client_id     = var.client_id
+    client_secret = var.client_secret
+  }
+
+  form_body_values = {
+    scope = ["read", "write"]
+  }
+}
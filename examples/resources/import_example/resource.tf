@@ -0,0 +1,30 @@
+import {
+  to = terracurl_request.mount
+  id = jsonencode({
+    name                = "vault-aws-mount"
+    url                 = "http://localhost:8200/v1/sys/mounts/aws"
+    method              = "POST"
+    response_codes      = ["200", "204"]
+    read_url            = "http://localhost:8200/v1/sys/mounts/aws"
+    read_method         = "GET"
+    read_response_codes = ["200"]
+    read_headers = {
+      X-Vault-Token = "root"
+    }
+  })
+}
+
+resource "terracurl_request" "mount" {
+  name           = "vault-aws-mount"
+  url            = "http://localhost:8200/v1/sys/mounts/aws"
+  method         = "POST"
+  response_codes = ["200", "204"]
+
+  read_url            = "http://localhost:8200/v1/sys/mounts/aws"
+  read_method         = "GET"
+  read_response_codes = ["200"]
+
+  read_headers = {
+    X-Vault-Token = "root"
+  }
+}
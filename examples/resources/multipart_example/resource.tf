@@ -0,0 +1,30 @@
+resource "terracurl_request" "upload" {
+  name           = "upload"
+  url            = "https://api.example.com/artifacts"
+  method         = "POST"
+  response_codes = ["201"]
+
+  multipart {
+    field {
+      name  = "version"
+      value = "1.0.0"
+    }
+
+    file {
+      name         = "artifact"
+      path         = "${path.module}/build/artifact.zip"
+      content_type = "application/zip"
+    }
+
+    file {
+      name         = "metadata"
+      content      = jsonencode({ commit = "4f2a9c1" })
+      filename     = "metadata.json"
+      content_type = "application/json"
+    }
+  }
+
+  destroy_url            = "https://api.example.com/artifacts/1.0.0"
+  destroy_method         = "DELETE"
+  destroy_response_codes = ["204"]
+}
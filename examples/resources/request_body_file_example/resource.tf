@@ -0,0 +1,20 @@
+resource "terracurl_request" "dashboard" {
+  name              = "dashboard"
+  url               = "https://grafana.example.com/api/dashboards/db"
+  method            = "POST"
+  request_body_file = "${path.module}/dashboards/overview.json"
+  response_codes    = ["200"]
+
+  headers = {
+    Content-Type = "application/json"
+  }
+
+  # Changing the content of the file sends the update request.
+  update_url               = "https://grafana.example.com/api/dashboards/db"
+  update_method            = "POST"
+  update_request_body_file = "${path.module}/dashboards/overview.json"
+  update_response_codes    = ["200"]
+  update_headers = {
+    Content-Type = "application/json"
+  }
+}
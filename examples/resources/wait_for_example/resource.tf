@@ -0,0 +1,23 @@
+resource "terracurl_request" "cluster" {
+  name           = "cluster"
+  url            = "https://api.example.com/v1/clusters"
+  method         = "POST"
+  response_codes = ["202"]
+
+  request_body = jsonencode({
+    name = "example"
+  })
+
+  headers = {
+    Content-Type = "application/json"
+  }
+
+  wait_for {
+    url            = "https://api.example.com/v1/operations/{{ .Body.operation_id }}"
+    path           = "status"
+    success_values = ["DONE"]
+    failure_values = ["FAILED", "CANCELLED"]
+    interval       = 10
+    timeout        = 900
+  }
+}
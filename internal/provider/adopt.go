@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// adoptExisting sends the configured read request and, if the remote object
+// exists, fills data as if the object had just been created. It reports
+// whether the object was adopted. An unexpected read response code means
+// there is nothing to adopt.
+func (r *CurlResource) adoptExisting(ctx context.Context, data *CurlResourceModel, private privateStateWriter) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if data.ReadUrl.IsNull() || data.ReadMethod.IsNull() || data.ReadResponseCodes.IsNull() {
+		diags.AddError(
+			"Invalid Configuration",
+			"`read_url`, `read_method`, and `read_response_codes` must be provided to adopt existing objects.",
+		)
+		return false, diags
+	}
+
+	client, request, err := newReadRequest(ctx, data)
+	if err != nil {
+		diags.AddError("Adopt Error", err.Error())
+		return false, diags
+	}
+
+	readOptions, optionDiags := r.readRequestOptions(ctx, data)
+	diags.Append(optionDiags...)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Looking up existing object at %s", request.URL.String()))
+
+	result, err := executeRequest(ctx, client, request, readOptions)
+	if err != nil {
+		var statusErr *unexpectedStatusError
+		if errors.As(err, &statusErr) {
+			tflog.Debug(ctx, fmt.Sprintf("No existing object to adopt, read request returned status code %d", statusErr.Result.StatusCode))
+			return false, diags
+		}
+		addRequestError(&diags, err)
+		return false, diags
+	}
+
+	data.DriftMarker = types.StringValue("initial")
+	data.DestroyRequestUrlString = types.StringValue(data.DestroyUrl.ValueString())
+	diags.Append(data.setResponse(ctx, result.Body)...)
+	data.StatusCode = types.StringValue(strconv.Itoa(result.StatusCode))
+	data.WaitForResponse = types.StringNull()
+
+	diags.Append(saveResourceVersion(ctx, private, result)...)
+	diags.Append(saveReadVersion(ctx, private, result)...)
+
+	tflog.Info(ctx, fmt.Sprintf("Adopted existing object from %s", request.URL.String()))
+	return true, diags
+}
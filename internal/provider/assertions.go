@@ -0,0 +1,412 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	eschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// AssertionModel describes a single `assert` block.
+type AssertionModel struct {
+	Path         types.String `tfsdk:"path"`
+	Equals       types.String `tfsdk:"equals"`
+	Matches      types.String `tfsdk:"matches"`
+	Exists       types.Bool   `tfsdk:"exists"`
+	ContentType  types.String `tfsdk:"content_type"`
+	MaxLatencyMs types.Int64  `tfsdk:"max_latency_ms"`
+}
+
+// assertionObjectType is the element type of every `*assert` block list.
+var assertionObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"path":           types.StringType,
+		"equals":         types.StringType,
+		"matches":        types.StringType,
+		"exists":         types.BoolType,
+		"content_type":   types.StringType,
+		"max_latency_ms": types.Int64Type,
+	},
+}
+
+// emptyAssertions returns an `*assert` block list without any blocks.
+func emptyAssertions() types.List {
+	return types.ListValueMust(assertionObjectType, []attr.Value{})
+}
+
+// responseAssertion is the evaluated form of an AssertionModel. It is also
+// stored in ephemeral private data, so it must remain JSON serialisable.
+type responseAssertion struct {
+	Path        string        `json:"path,omitempty"`
+	Equals      *string       `json:"equals,omitempty"`
+	Matches     string        `json:"matches,omitempty"`
+	Exists      *bool         `json:"exists,omitempty"`
+	ContentType string        `json:"content_type,omitempty"`
+	MaxLatency  time.Duration `json:"max_latency,omitempty"`
+}
+
+// assertionFailure describes why a single assertion did not hold.
+type assertionFailure struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+// assertionError is returned by executeRequest when the last attempt received
+// an expected status code but at least one assertion failed.
+type assertionError struct {
+	Result   *httpResult
+	Failures []assertionFailure
+}
+
+func (e *assertionError) Error() string {
+	var lines []string
+	for _, failure := range e.Failures {
+		if failure.Path != "" {
+			lines = append(lines, fmt.Sprintf("%s: expected %s, got %s", failure.Path, failure.Expected, failure.Actual))
+		} else {
+			lines = append(lines, fmt.Sprintf("expected %s, got %s", failure.Expected, failure.Actual))
+		}
+	}
+	return fmt.Sprintf("%d assertion(s) failed for status code %d:\n%s", len(e.Failures), e.Result.StatusCode, strings.Join(lines, "\n"))
+}
+
+// assertionsFromList converts an `*assert` block list into responseAssertions.
+func assertionsFromList(ctx context.Context, list types.List) ([]responseAssertion, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if list.IsNull() || list.IsUnknown() {
+		return nil, diags
+	}
+
+	var models []AssertionModel
+	diags.Append(list.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	assertions := make([]responseAssertion, 0, len(models))
+	for _, model := range models {
+		assertion := responseAssertion{
+			Path:        model.Path.ValueString(),
+			Matches:     model.Matches.ValueString(),
+			ContentType: model.ContentType.ValueString(),
+			MaxLatency:  time.Duration(model.MaxLatencyMs.ValueInt64()) * time.Millisecond,
+		}
+		if !model.Equals.IsNull() {
+			equals := model.Equals.ValueString()
+			assertion.Equals = &equals
+		}
+		if !model.Exists.IsNull() {
+			exists := model.Exists.ValueBool()
+			assertion.Exists = &exists
+		}
+		assertions = append(assertions, assertion)
+	}
+
+	return assertions, diags
+}
+
+// checkAssertions evaluates every assertion against result and returns the
+// failures, if any.
+func checkAssertions(assertions []responseAssertion, result *httpResult) []assertionFailure {
+	if len(assertions) == 0 {
+		return nil
+	}
+
+	var failures []assertionFailure
+
+	var document interface{}
+	var decodeErr error
+	decoded := false
+
+	for _, assertion := range assertions {
+		if assertion.ContentType != "" {
+			actual := result.Header.Get("Content-Type")
+			mediaType, _, err := mime.ParseMediaType(actual)
+			if err != nil || !strings.EqualFold(mediaType, assertion.ContentType) {
+				failures = append(failures, assertionFailure{
+					Expected: fmt.Sprintf("content type %q", assertion.ContentType),
+					Actual:   fmt.Sprintf("%q", actual),
+				})
+			}
+		}
+
+		if assertion.MaxLatency > 0 && result.Latency > assertion.MaxLatency {
+			failures = append(failures, assertionFailure{
+				Expected: fmt.Sprintf("latency of at most %s", assertion.MaxLatency),
+				Actual:   result.Latency.Round(time.Millisecond).String(),
+			})
+		}
+
+		if assertion.Path == "" {
+			continue
+		}
+
+		if !decoded {
+			document, decodeErr = decodeJSON(result.Body)
+			decoded = true
+		}
+		if decodeErr != nil {
+			failures = append(failures, assertionFailure{
+				Path:     assertion.Path,
+				Expected: "a JSON response body",
+				Actual:   fmt.Sprintf("a body that could not be parsed (%s)", decodeErr),
+			})
+			continue
+		}
+
+		value, exists, err := lookupJSONPath(document, assertion.Path)
+		if err != nil {
+			failures = append(failures, assertionFailure{
+				Path:     assertion.Path,
+				Expected: "a valid JSON path",
+				Actual:   err.Error(),
+			})
+			continue
+		}
+
+		if assertion.Exists != nil && exists != *assertion.Exists {
+			expected, actual := "the path to exist", "it is absent"
+			if !*assertion.Exists {
+				expected, actual = "the path to be absent", fmt.Sprintf("%q", jsonValueString(value))
+			}
+			failures = append(failures, assertionFailure{Path: assertion.Path, Expected: expected, Actual: actual})
+			continue
+		}
+
+		if assertion.Equals == nil && assertion.Matches == "" {
+			continue
+		}
+
+		if !exists {
+			failures = append(failures, assertionFailure{
+				Path:     assertion.Path,
+				Expected: describeExpectedValue(assertion),
+				Actual:   "it is absent",
+			})
+			continue
+		}
+
+		actual := jsonValueString(value)
+		if assertion.Equals != nil && actual != *assertion.Equals {
+			failures = append(failures, assertionFailure{
+				Path:     assertion.Path,
+				Expected: fmt.Sprintf("%q", *assertion.Equals),
+				Actual:   fmt.Sprintf("%q", actual),
+			})
+			continue
+		}
+
+		if assertion.Matches != "" {
+			re, err := regexp.Compile(assertion.Matches)
+			if err != nil || !re.MatchString(actual) {
+				failures = append(failures, assertionFailure{
+					Path:     assertion.Path,
+					Expected: fmt.Sprintf("a value matching %q", assertion.Matches),
+					Actual:   fmt.Sprintf("%q", actual),
+				})
+			}
+		}
+	}
+
+	return failures
+}
+
+func describeExpectedValue(assertion responseAssertion) string {
+	if assertion.Equals != nil {
+		return fmt.Sprintf("%q", *assertion.Equals)
+	}
+	return fmt.Sprintf("a value matching %q", assertion.Matches)
+}
+
+const assertBlockDescription = "Assertions evaluated against the response of the %s call. A failing assertion is treated like an unexpected response code: the call is retried and, once retries are exhausted, the failing path and actual value are reported."
+
+const (
+	assertPathDescription         = "JSON path into the response body, e.g. `status` or `data.items[0].id`."
+	assertEqualsDescription       = "Expected value at `path`. Strings are compared without quotes, other values are compared in their JSON form."
+	assertMatchesDescription      = "Regular expression that the value at `path` must match."
+	assertExistsDescription       = "Set to `true` to require `path` to be present or to `false` to require it to be absent."
+	assertContentTypeDescription  = "Expected media type of the response, e.g. `application/json`. Parameters such as `charset` are ignored."
+	assertMaxLatencyMsDescription = "Maximum time in milliseconds the call may take."
+)
+
+// assertionChecks are the attributes of an `assert` block that define a
+// check; `path` only selects what `equals`, `matches` and `exists` look at.
+var assertionChecks = []string{"equals", "matches", "exists", "content_type", "max_latency_ms"}
+
+var _ validator.Object = assertionCheckValidator{}
+
+// assertionCheckValidator rejects `assert` blocks that do not define a check,
+// which would otherwise always pass.
+type assertionCheckValidator struct{}
+
+func (v assertionCheckValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("at least one of %s must be set", strings.Join(assertionChecks, ", "))
+}
+
+func (v assertionCheckValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v assertionCheckValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+	for _, name := range assertionChecks {
+		if value, ok := attributes[name]; ok && !value.IsNull() {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Missing Assertion Check",
+		fmt.Sprintf("An `assert` block must set at least one of %s.", strings.Join(assertionChecks, ", ")),
+	)
+}
+
+func assertionStringValidators(requiresPath bool, extra ...validator.String) []validator.String {
+	validators := append([]validator.String{}, extra...)
+	if requiresPath {
+		validators = append(validators, stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("path")))
+	}
+	return validators
+}
+
+func assertionResourceBlock(operation string) rschema.ListNestedBlock {
+	return rschema.ListNestedBlock{
+		MarkdownDescription: fmt.Sprintf(assertBlockDescription, operation),
+		NestedObject: rschema.NestedBlockObject{
+			Attributes: map[string]rschema.Attribute{
+				"path": rschema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: assertPathDescription,
+					Validators:          assertionStringValidators(false, jsonPathValidator{}),
+				},
+				"equals": rschema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: assertEqualsDescription,
+					Validators:          assertionStringValidators(true),
+				},
+				"matches": rschema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: assertMatchesDescription,
+					Validators:          assertionStringValidators(true, regexValidator{}),
+				},
+				"exists": rschema.BoolAttribute{
+					Optional:            true,
+					MarkdownDescription: assertExistsDescription,
+					Validators: []validator.Bool{
+						boolvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("path")),
+					},
+				},
+				"content_type": rschema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: assertContentTypeDescription,
+				},
+				"max_latency_ms": rschema.Int64Attribute{
+					Optional:            true,
+					MarkdownDescription: assertMaxLatencyMsDescription,
+				},
+			},
+			Validators: []validator.Object{assertionCheckValidator{}},
+		},
+	}
+}
+
+func assertionDataSourceBlock(operation string) dschema.ListNestedBlock {
+	return dschema.ListNestedBlock{
+		MarkdownDescription: fmt.Sprintf(assertBlockDescription, operation),
+		NestedObject: dschema.NestedBlockObject{
+			Attributes: map[string]dschema.Attribute{
+				"path": dschema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: assertPathDescription,
+					Validators:          assertionStringValidators(false, jsonPathValidator{}),
+				},
+				"equals": dschema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: assertEqualsDescription,
+					Validators:          assertionStringValidators(true),
+				},
+				"matches": dschema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: assertMatchesDescription,
+					Validators:          assertionStringValidators(true, regexValidator{}),
+				},
+				"exists": dschema.BoolAttribute{
+					Optional:            true,
+					MarkdownDescription: assertExistsDescription,
+					Validators: []validator.Bool{
+						boolvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("path")),
+					},
+				},
+				"content_type": dschema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: assertContentTypeDescription,
+				},
+				"max_latency_ms": dschema.Int64Attribute{
+					Optional:            true,
+					MarkdownDescription: assertMaxLatencyMsDescription,
+				},
+			},
+			Validators: []validator.Object{assertionCheckValidator{}},
+		},
+	}
+}
+
+func assertionEphemeralBlock(operation string) eschema.ListNestedBlock {
+	return eschema.ListNestedBlock{
+		MarkdownDescription: fmt.Sprintf(assertBlockDescription, operation),
+		NestedObject: eschema.NestedBlockObject{
+			Attributes: map[string]eschema.Attribute{
+				"path": eschema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: assertPathDescription,
+					Validators:          assertionStringValidators(false, jsonPathValidator{}),
+				},
+				"equals": eschema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: assertEqualsDescription,
+					Validators:          assertionStringValidators(true),
+				},
+				"matches": eschema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: assertMatchesDescription,
+					Validators:          assertionStringValidators(true, regexValidator{}),
+				},
+				"exists": eschema.BoolAttribute{
+					Optional:            true,
+					MarkdownDescription: assertExistsDescription,
+					Validators: []validator.Bool{
+						boolvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("path")),
+					},
+				},
+				"content_type": eschema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: assertContentTypeDescription,
+				},
+				"max_latency_ms": eschema.Int64Attribute{
+					Optional:            true,
+					MarkdownDescription: assertMaxLatencyMsDescription,
+				},
+			},
+			Validators: []validator.Object{assertionCheckValidator{}},
+		},
+	}
+}
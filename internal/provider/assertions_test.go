@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCheckAssertions(t *testing.T) {
+	ok := "ok"
+	yes := true
+	no := false
+
+	result := &httpResult{
+		StatusCode: 200,
+		Body:       []byte(`{"status":"error","id":"abc-123"}`),
+		Header:     http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+		Latency:    50 * time.Millisecond,
+	}
+
+	tests := []struct {
+		name         string
+		assertion    responseAssertion
+		expectFailed bool
+		contains     string
+	}{
+		{name: "Equals mismatch", assertion: responseAssertion{Path: "status", Equals: &ok}, expectFailed: true, contains: `status: expected "ok", got "error"`},
+		{name: "Matches", assertion: responseAssertion{Path: "id", Matches: "^abc-[0-9]+$"}},
+		{name: "Matches mismatch", assertion: responseAssertion{Path: "id", Matches: "^xyz"}, expectFailed: true, contains: `got "abc-123"`},
+		{name: "Exists", assertion: responseAssertion{Path: "id", Exists: &yes}},
+		{name: "Exists missing", assertion: responseAssertion{Path: "name", Exists: &yes}, expectFailed: true, contains: "it is absent"},
+		{name: "Absent", assertion: responseAssertion{Path: "name", Exists: &no}},
+		{name: "Absent but present", assertion: responseAssertion{Path: "status", Exists: &no}, expectFailed: true, contains: `got "error"`},
+		{name: "Content type ignores parameters", assertion: responseAssertion{ContentType: "Application/JSON"}},
+		{name: "Content type mismatch", assertion: responseAssertion{ContentType: "text/plain"}, expectFailed: true, contains: "content type"},
+		{name: "Latency within limit", assertion: responseAssertion{MaxLatency: time.Second}},
+		{name: "Latency over limit", assertion: responseAssertion{MaxLatency: 10 * time.Millisecond}, expectFailed: true, contains: "latency"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures := checkAssertions([]responseAssertion{tt.assertion}, result)
+			if !tt.expectFailed {
+				if len(failures) > 0 {
+					t.Errorf("expected no failures, got %v", failures)
+				}
+				return
+			}
+			if len(failures) != 1 {
+				t.Fatalf("expected 1 failure, got %d", len(failures))
+			}
+			message := (&assertionError{Result: result, Failures: failures}).Error()
+			if !strings.Contains(message, tt.contains) {
+				t.Errorf("expected %q to contain %q", message, tt.contains)
+			}
+		})
+	}
+}
+
+func TestCheckAssertionsNonJSONBody(t *testing.T) {
+	ok := "ok"
+	result := &httpResult{StatusCode: 200, Body: []byte("not json"), Header: http.Header{}}
+
+	failures := checkAssertions([]responseAssertion{{Path: "status", Equals: &ok}}, result)
+	if len(failures) != 1 || failures[0].Expected != "a JSON response body" {
+		t.Errorf("expected a single JSON body failure, got %v", failures)
+	}
+}
+
+func TestAssertionBlocksRequireCheck(t *testing.T) {
+	ctx := context.Background()
+
+	attributeTypes := map[string]attr.Type{
+		"path":           types.StringType,
+		"equals":         types.StringType,
+		"matches":        types.StringType,
+		"exists":         types.BoolType,
+		"content_type":   types.StringType,
+		"max_latency_ms": types.Int64Type,
+	}
+	assertion := func(values map[string]attr.Value) types.Object {
+		attributes := map[string]attr.Value{
+			"path":           types.StringNull(),
+			"equals":         types.StringNull(),
+			"matches":        types.StringNull(),
+			"exists":         types.BoolNull(),
+			"content_type":   types.StringNull(),
+			"max_latency_ms": types.Int64Null(),
+		}
+		for name, value := range values {
+			attributes[name] = value
+		}
+		return types.ObjectValueMust(attributeTypes, attributes)
+	}
+
+	testCases := map[string]struct {
+		value types.Object
+		error bool
+	}{
+		"Empty block":   {value: assertion(nil), error: true},
+		"Only path":     {value: assertion(map[string]attr.Value{"path": types.StringValue("status")}), error: true},
+		"Equals":        {value: assertion(map[string]attr.Value{"path": types.StringValue("status"), "equals": types.StringValue("ok")}), error: false},
+		"Exists false":  {value: assertion(map[string]attr.Value{"path": types.StringValue("error"), "exists": types.BoolValue(false)}), error: false},
+		"Content type":  {value: assertion(map[string]attr.Value{"content_type": types.StringValue("application/json")}), error: false},
+		"Max latency":   {value: assertion(map[string]attr.Value{"max_latency_ms": types.Int64Value(500)}), error: false},
+		"Unknown check": {value: assertion(map[string]attr.Value{"equals": types.StringUnknown()}), error: false},
+	}
+
+	blocks := map[string][]validator.Object{
+		"resource":    assertionResourceBlock("create").NestedObject.Validators,
+		"data source": assertionDataSourceBlock("read").NestedObject.Validators,
+		"ephemeral":   assertionEphemeralBlock("open").NestedObject.Validators,
+	}
+
+	for block, validators := range blocks {
+		for name, tc := range testCases {
+			t.Run(block+"/"+name, func(t *testing.T) {
+				resp := &validator.ObjectResponse{}
+				for _, v := range validators {
+					v.ValidateObject(ctx, validator.ObjectRequest{
+						Path:        path.Root("assert").AtListIndex(0),
+						ConfigValue: tc.value,
+					}, resp)
+				}
+				if resp.Diagnostics.HasError() != tc.error {
+					t.Errorf("expected error %t, got %v", tc.error, resp.Diagnostics)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const readVersionPrivateKey = "read_version"
+
+// readVersion holds the validators of the last full read response and its
+// size, which is what a 304 Not Modified saves downloading.
+type readVersion struct {
+	resourceVersion
+	Size int `json:"size"`
+}
+
+// conditionalReadSavings counts the reads answered with 304 Not Modified by
+// this provider process.
+var conditionalReadSavings struct {
+	requests atomic.Int64
+	bytes    atomic.Int64
+}
+
+// setConditionalReadHeaders makes request conditional on the remote object
+// having changed since the last full read. It returns the stored version, or
+// nil if no validators are known or the read method is not GET or HEAD, for
+// which If-None-Match would be a precondition rather than a cache check.
+func setConditionalReadHeaders(ctx context.Context, private privateStateReader, request *http.Request) (*readVersion, diag.Diagnostics) {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		return nil, nil
+	}
+
+	var version readVersion
+	found, diags := getPrivateJSON(ctx, private, readVersionPrivateKey, &version)
+	if diags.HasError() || !found || version.isEmpty() {
+		return nil, diags
+	}
+
+	if version.ETag != "" && request.Header.Get("If-None-Match") == "" {
+		request.Header.Set("If-None-Match", version.ETag)
+	}
+	if version.LastModified != "" && request.Header.Get("If-Modified-Since") == "" {
+		request.Header.Set("If-Modified-Since", version.LastModified)
+	}
+	return &version, diags
+}
+
+// saveReadVersion stores the validators of a full read response in private
+// state, or removes them if the response has none.
+func saveReadVersion(ctx context.Context, private privateStateWriter, result *httpResult) diag.Diagnostics {
+	version := readVersion{
+		resourceVersion: resourceVersionFromResult(result),
+		Size:            len(result.Body),
+	}
+	if version.isEmpty() {
+		return private.SetKey(ctx, readVersionPrivateKey, nil)
+	}
+	return setPrivateJSON(ctx, private, readVersionPrivateKey, version)
+}
+
+// recordNotModified logs the request and bytes saved by a 304 response.
+func recordNotModified(ctx context.Context, request *http.Request, version *readVersion) {
+	requests := conditionalReadSavings.requests.Add(1)
+	bytes := conditionalReadSavings.bytes.Add(int64(version.Size))
+	tflog.Debug(ctx, fmt.Sprintf("Read of %s was not modified, skipped downloading %d bytes. Conditional reads have saved %d full responses and %d bytes so far", request.URL.String(), version.Size, requests, bytes))
+}
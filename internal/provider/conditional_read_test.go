@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestConditionalReadHeaders(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateState{}
+
+	request, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	version, diags := setConditionalReadHeaders(ctx, private, request)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if version != nil || request.Header.Get("If-None-Match") != "" {
+		t.Fatal("expected an unconditional request without a previous read")
+	}
+
+	result := &httpResult{Header: http.Header{}, Body: []byte(`{"name":"test"}`)}
+	result.Header.Set("ETag", `"r1"`)
+	result.Header.Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+	if diags := saveReadVersion(ctx, private, result); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	request, _ = http.NewRequest(http.MethodGet, "https://example.com", nil)
+	version, diags = setConditionalReadHeaders(ctx, private, request)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if version == nil || version.Size != len(result.Body) {
+		t.Fatalf("unexpected version %+v", version)
+	}
+	if got := request.Header.Get("If-None-Match"); got != `"r1"` {
+		t.Errorf("unexpected If-None-Match %q", got)
+	}
+	if got := request.Header.Get("If-Modified-Since"); got != "Wed, 21 Oct 2015 07:28:00 GMT" {
+		t.Errorf("unexpected If-Modified-Since %q", got)
+	}
+
+	request, _ = http.NewRequest(http.MethodPost, "https://example.com", nil)
+	version, diags = setConditionalReadHeaders(ctx, private, request)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if version != nil || request.Header.Get("If-None-Match") != "" || request.Header.Get("If-Modified-Since") != "" {
+		t.Error("expected a POST read request to be sent unconditionally")
+	}
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Values of `on_create_failure`.
+const (
+	createFailureError   = "error"
+	createFailureTaint   = "taint"
+	createFailureDestroy = "destroy"
+)
+
+// createMayExist reports whether a create error happened after the API
+// answered with a success status, in which case the remote object probably
+// exists. Any other status, e.g. 400 or 409, means the object was not
+// created, so there is nothing to taint or roll back.
+func createMayExist(err error) (*httpResult, bool) {
+	var statusErr *unexpectedStatusError
+	var assertErr *assertionError
+
+	var result *httpResult
+	switch {
+	case errors.As(err, &statusErr):
+		result = statusErr.Result
+	case errors.As(err, &assertErr):
+		result = assertErr.Result
+	default:
+		return nil, false
+	}
+	return result, result.StatusCode >= 200 && result.StatusCode < 300
+}
+
+// handleCreateFailure applies `on_create_failure` once the create request has
+// succeeded but a later step failed. The failure itself must already be
+// reported in resp.
+func (r *CurlResource) handleCreateFailure(ctx context.Context, data *CurlResourceModel, result *httpResult, resp *resource.CreateResponse) {
+	switch data.OnCreateFailure.ValueString() {
+	case createFailureTaint:
+		r.saveTaintedState(ctx, data, result, resp)
+		resp.Diagnostics.AddWarning(
+			"Resource Saved As Tainted",
+			"The create request reached the API but a later step failed. The resource has been saved to state as tainted, so it is destroyed and created again on the next apply.",
+		)
+	case createFailureDestroy:
+		rollbackResult, err := r.rollbackCreate(ctx, data, resp)
+		if err != nil {
+			r.saveTaintedState(ctx, data, result, resp)
+			resp.Diagnostics.AddError(
+				"Rollback Failed",
+				fmt.Sprintf("The destroy request sent to remove the object of the failed create did not succeed: %s. The resource has been saved to state as tainted instead, so it is destroyed and created again on the next apply.", err),
+			)
+			return
+		}
+		resp.Diagnostics.AddWarning(
+			"Create Rolled Back",
+			fmt.Sprintf("The create request reached the API but a later step failed, so the destroy request was sent to remove the object. It returned status code %d.", rollbackResult.StatusCode),
+		)
+	}
+}
+
+// saveTaintedState saves what is known about the partially created object.
+// Terraform marks a resource saved together with an error as tainted.
+func (r *CurlResource) saveTaintedState(ctx context.Context, data *CurlResourceModel, result *httpResult, resp *resource.CreateResponse) {
+	data.DriftMarker = types.StringValue("initial")
+	data.DestroyRequestUrlString = types.StringValue(data.DestroyUrl.ValueString())
+	resp.Diagnostics.Append(data.setResponse(ctx, result.Body)...)
+	data.StatusCode = types.StringValue(strconv.Itoa(result.StatusCode))
+	if data.WaitForResponse.IsUnknown() {
+		data.WaitForResponse = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// rollbackCreate sends the configured destroy request for an object whose
+// create failed.
+func (r *CurlResource) rollbackCreate(ctx context.Context, data *CurlResourceModel, resp *resource.CreateResponse) (*httpResult, error) {
+	if data.DestroyUrl.IsNull() || data.DestroyMethod.IsNull() || data.DestroyResponseCodes.IsNull() {
+		return nil, errors.New("`destroy_url`, `destroy_method`, and `destroy_response_codes` are required to roll back a create")
+	}
+
+	// An interrupted create is still rolled back.
+	if ctx.Err() != nil {
+		ctx = context.WithoutCancel(ctx)
+	}
+
+	client, request, err := newDestroyRequest(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.IdempotencyKey.ValueBool() {
+		idempotencyKey, diags := resourceIdempotencyKey(ctx, resp.Private)
+		if diags.HasError() {
+			return nil, errors.New("failed to load the idempotency key")
+		}
+		setIdempotencyKey(request, data.IdempotencyKeyHeader.ValueString(), operationIdempotencyKey(idempotencyKey, "destroy", 0, request, hashOrBody(data.DestroyRequestBodyFileSha256, formOrBody(data.DestroyRequestBody, data.DestroyFormBody, data.DestroyFormBodyValues))))
+	}
+
+	destroyOptions, diags := r.destroyRequestOptions(ctx, data)
+	if diags.HasError() {
+		return nil, errors.New("invalid destroy request options")
+	}
+	destroyOptions.Operation = "Rollback"
+
+	tflog.Warn(ctx, fmt.Sprintf("Rolling back failed create with %s %s", request.Method, request.URL.String()))
+	return executeRequest(ctx, client, request, destroyOptions)
+}
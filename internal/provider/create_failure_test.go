@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCreateMayExist(t *testing.T) {
+	success := &httpResult{StatusCode: 202}
+	conflict := &httpResult{StatusCode: 409}
+	serverError := &httpResult{StatusCode: 500}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "Unexpected success code", err: &unexpectedStatusError{Result: success}, expected: true},
+		{name: "Failed assertion", err: &assertionError{Result: success}, expected: true},
+		{name: "Wrapped response error", err: fmt.Errorf("create: %w", &unexpectedStatusError{Result: success}), expected: true},
+		{name: "Client error", err: &unexpectedStatusError{Result: conflict}, expected: false},
+		{name: "Failed assertion on a client error", err: &assertionError{Result: conflict}, expected: false},
+		{name: "Server error", err: &unexpectedStatusError{Result: serverError}, expected: false},
+		{name: "Transport error", err: errors.New("connection refused"), expected: false},
+		{name: "Interrupted", err: &interruptedError{Operation: "Create", Cause: errors.New("deadline exceeded")}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, exists := createMayExist(tt.err)
+			if exists != tt.expected {
+				t.Fatalf("expected exists to be %t, got %t", tt.expected, exists)
+			}
+			if exists && got != success {
+				t.Errorf("expected the response of the create request to be returned")
+			}
+		})
+	}
+}
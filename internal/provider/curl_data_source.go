@@ -4,13 +4,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -18,11 +17,14 @@ import (
 
 // Ensure the implementation satisfies the desired interfaces.
 var _ datasource.DataSource = &CurlDataSource{}
+var _ datasource.DataSourceWithConfigure = &CurlDataSource{}
 
 type ThingDataSource struct{}
 
 type CurlDataSource struct {
 	//client *http.Client
+	retryPolicy *retryPolicy
+	logging     *loggingOptions
 }
 
 func NewCurlDataSource() datasource.DataSource {
@@ -30,25 +32,33 @@ func NewCurlDataSource() datasource.DataSource {
 }
 
 type CurlDataSourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	Url               types.String `tfsdk:"url"`
-	Method            types.String `tfsdk:"method"`
-	RequestBody       types.String `tfsdk:"request_body"`
-	Headers           types.Map    `tfsdk:"headers"`
-	RequestParameters types.Map    `tfsdk:"request_parameters"`
-	RequestUrlString  types.String `tfsdk:"request_url_string"`
-	CertFile          types.String `tfsdk:"cert_file"`
-	KeyFile           types.String `tfsdk:"key_file"`
-	CaCertFile        types.String `tfsdk:"ca_cert_file"`
-	CaCertDirectory   types.String `tfsdk:"ca_cert_directory"`
-	SkipTlsVerify     types.Bool   `tfsdk:"skip_tls_verify"`
-	RetryInterval     types.Int64  `tfsdk:"retry_interval"`
-	MaxRetry          types.Int64  `tfsdk:"max_retry"`
-	Timeout           types.Int64  `tfsdk:"timeout"`
-	Response          types.String `tfsdk:"response"`
-	ResponseCodes     types.List   `tfsdk:"response_codes"`
-	StatusCode        types.String `tfsdk:"status_code"`
+	ID                      types.String   `tfsdk:"id"`
+	Name                    types.String   `tfsdk:"name"`
+	Url                     types.String   `tfsdk:"url"`
+	Method                  types.String   `tfsdk:"method"`
+	RequestBody             types.String   `tfsdk:"request_body"`
+	Headers                 types.Map      `tfsdk:"headers"`
+	RequestParameters       types.Map      `tfsdk:"request_parameters"`
+	RequestUrlString        types.String   `tfsdk:"request_url_string"`
+	CertFile                types.String   `tfsdk:"cert_file"`
+	KeyFile                 types.String   `tfsdk:"key_file"`
+	CaCertFile              types.String   `tfsdk:"ca_cert_file"`
+	CaCertDirectory         types.String   `tfsdk:"ca_cert_directory"`
+	SkipTlsVerify           types.Bool     `tfsdk:"skip_tls_verify"`
+	RetryInterval           types.Int64    `tfsdk:"retry_interval"`
+	MaxRetry                types.Int64    `tfsdk:"max_retry"`
+	Timeout                 types.Int64    `tfsdk:"timeout"`
+	Response                types.String   `tfsdk:"response"`
+	ResponseCodes           types.List     `tfsdk:"response_codes"`
+	StatusCode              types.String   `tfsdk:"status_code"`
+	Assert                  types.List     `tfsdk:"assert"`
+	RetryPolicy             types.Object   `tfsdk:"retry_policy"`
+	Multipart               types.Object   `tfsdk:"multipart"`
+	FormBody                types.Map      `tfsdk:"form_body"`
+	FormBodyValues          types.Map      `tfsdk:"form_body_values"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+	SensitiveResponseFields types.List     `tfsdk:"sensitive_response_fields"`
+	SensitiveResponse       types.Map      `tfsdk:"sensitive_response"`
 }
 
 func (d *CurlDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -79,6 +89,16 @@ func (d *CurlDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Optional:            true,
 				MarkdownDescription: "A request body to attach to the API call",
 			},
+			"form_body": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyDescription, "data source", "`request_body` and `multipart`"),
+			},
+			"form_body_values": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyValuesDescription, "data source", "form_body"),
+			},
 			"headers": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
@@ -130,17 +150,58 @@ func (d *CurlDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Computed:            true,
 				MarkdownDescription: "JSON response received from request",
 			},
+			"sensitive_response_fields": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: sensitiveResponseFieldsDescription,
+			},
+			"sensitive_response": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Values of the `sensitive_response_fields` found in the response, keyed by JSON path",
+			},
 			"response_codes": schema.ListAttribute{
 				Required:            true,
-				MarkdownDescription: "A list of expected response codes",
+				MarkdownDescription: "A list of expected response codes. Supports exact codes (`200`), classes (`2xx`), inclusive ranges (`200-299`) and negations (`!409`).",
 				ElementType:         types.StringType,
+				Validators:          validResponseCodes(),
 			},
 			"status_code": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Response status code received from request",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"assert":       assertionDataSourceBlock("data source"),
+			"retry_policy": retryPolicyDataSourceBlock("data source"),
+			"multipart":    multipartDataSourceBlock(),
+			"timeouts": timeouts.BlockWithOpts(ctx, timeouts.Opts{
+				ReadDescription: fmt.Sprintf(timeoutDescription, "read"),
+			}),
+		},
+	}
+}
+
+func (d *CurlDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
 	}
+
+	d.retryPolicy = data.RetryPolicy
+	d.logging = data.Logging
 }
 
 func (d *CurlDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -148,6 +209,17 @@ func (d *CurlDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	// Read Terraform configuration data into the model.
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, readTimeout)
+	defer cancel()
 
 	data.ID = types.StringValue(data.Name.ValueString())
 
@@ -182,9 +254,7 @@ func (d *CurlDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	} else {
 		// Use default non-TLS client.
-		client = &http.Client{
-			Timeout: 30 * time.Second,
-		}
+		client = &http.Client{}
 	}
 
 	reqBody := []byte(data.RequestBody.ValueString())
@@ -215,69 +285,72 @@ func (d *CurlDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 	data.RequestUrlString = types.StringValue(request.URL.String())
 
-	tflog.Debug(ctx, fmt.Sprintf("Data Source API Call: \nURL: %s\nHeaders: %s\nMethod: %s\nRequest Body: %s\n", request.URL.String(), request.Header, request.Method, request.Body))
+	parts, diags := setMultipartBody(ctx, data.Multipart, request)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	loggedBody := data.RequestBody.ValueString()
+	if parts != nil {
+		loggedBody = parts.describe()
+	}
+	if form := formValues(data.FormBody, data.FormBodyValues); form != nil {
+		loggedBody = setFormBody(request, form)
+	}
+	ctx = d.logging.withMasking(ctx, request, loggedBody)
+	d.logging.logRequest(ctx, "Data source", request, loggedBody)
 
 	timeout := 10 * time.Second
 	if !data.Timeout.IsNull() {
 		timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
 	}
 
-	var body []byte
-	var bodyString string
-	var statusCode int
-	retryCount := 0
-
-	for {
-		ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
-
-		response, err := client.Do(request.WithContext(ctxWithTimeout))
-		if err != nil {
-			if retryCount < int(data.MaxRetry.ValueInt64()) {
-				retryCount++
-				time.Sleep(time.Duration(data.RetryInterval.ValueInt64()) * time.Second)
-				continue
-			}
-			resp.Diagnostics.AddError("Request Failed", err.Error())
-			return
+	var responseCodes []string
+	for _, v := range data.ResponseCodes.Elements() {
+		if strVal, ok := v.(types.String); ok {
+			responseCodes = append(responseCodes, strVal.ValueString())
 		}
+	}
 
-		defer func(Body io.ReadCloser) {
-			err := Body.Close()
-			if err != nil {
-				return
-			}
-		}(response.Body)
-		body, _ = io.ReadAll(response.Body)
-		statusCode = response.StatusCode
-
-		bodyString = string(body)
-		if bodyString == "" {
-			bodyString = "{}"
-		}
+	assertions, diags := assertionsFromList(ctx, data.Assert)
+	resp.Diagnostics.Append(diags...)
+	policy, diags := retryPolicyFromObject(ctx, data.RetryPolicy, d.retryPolicy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		var responseCodes []string
-		for _, v := range data.ResponseCodes.Elements() {
-			if strVal, ok := v.(types.String); ok {
-				responseCodes = append(responseCodes, strVal.ValueString())
-			}
-		}
+	result, err := executeRequest(ctx, client, request, requestOptions{
+		Operation:     "Data source",
+		MaxRetry:      int(data.MaxRetry.ValueInt64()),
+		RetryInterval: time.Duration(data.RetryInterval.ValueInt64()) * time.Second,
+		Timeout:       timeout,
+		ResponseCodes: responseCodes,
+		Assertions:    assertions,
+		RetryPolicy:   policy,
+		Logging:       d.logging,
+	})
+	if err != nil {
+		addRequestError(&resp.Diagnostics, err)
+		return
+	}
 
-		if responseCodeChecker(responseCodes, strconv.Itoa(statusCode)) {
-			break
-		}
+	statusCode := result.StatusCode
+	bodyString := string(result.Body)
+	if bodyString == "" {
+		bodyString = "{}"
+	}
 
-		if retryCount < int(data.MaxRetry.ValueInt64()) {
-			retryCount++
-			time.Sleep(time.Duration(data.RetryInterval.ValueInt64()) * time.Second)
-		} else {
-			resp.Diagnostics.AddError("Unexpected Response Code", fmt.Sprintf("Received status code: %d", statusCode))
-			return
-		}
+	redacted, sensitive, diags := redactResponseFields(ctx, bodyString, data.SensitiveResponseFields)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	data.RequestUrlString = types.StringValue(request.URL.String())
-	data.Response = types.StringValue(bodyString)
+	data.Response = types.StringValue(redacted)
+	data.SensitiveResponse = sensitive
 	data.StatusCode = types.StringValue(strconv.Itoa(statusCode))
 
 	// Save data into Terraform state.
@@ -285,7 +358,7 @@ func (d *CurlDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 }
 
 func (d CurlDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
-	return []datasource.ConfigValidator{
+	validators := []datasource.ConfigValidator{
 		datasourcevalidator.RequiredTogether(
 			path.MatchRoot("cert_file"),
 			path.MatchRoot("key_file"),
@@ -298,5 +371,14 @@ func (d CurlDataSource) ConfigValidators(ctx context.Context) []datasource.Confi
 			path.MatchRoot("max_retry"),
 			path.MatchRoot("retry_interval"),
 		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("request_body"),
+			path.MatchRoot("multipart"),
+		),
+	}
+
+	for _, pair := range formBodyConflicts("", "request_body", "multipart") {
+		validators = append(validators, datasourcevalidator.Conflicting(pair...))
 	}
+	return validators
 }
@@ -3,16 +3,19 @@ package provider
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-validators/ephemeralvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -21,9 +24,12 @@ import (
 var _ provider.ProviderWithEphemeralResources = (*TerraCurlProvider)(nil)
 var _ ephemeral.EphemeralResourceWithRenew = (*EphemeralCurlResource)(nil)
 var _ ephemeral.EphemeralResourceWithClose = (*EphemeralCurlResource)(nil)
+var _ ephemeral.EphemeralResourceWithConfigure = (*EphemeralCurlResource)(nil)
 
 type EphemeralCurlResource struct {
 	//client *http.Client
+	retryPolicy *retryPolicy
+	logging     *loggingOptions
 }
 
 func (e *EphemeralCurlResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
@@ -65,6 +71,7 @@ type CurlEphemeralModel struct {
 	SkipRenew         types.Bool   `tfsdk:"skip_renew"`
 
 	RenewInterval          types.Int64  `tfsdk:"renew_interval"`
+	RenewAtFrom            types.Object `tfsdk:"renew_at_from"`
 	RenewUrl               types.String `tfsdk:"renew_url"`
 	RenewMethod            types.String `tfsdk:"renew_method"`
 	RenewRequestBody       types.String `tfsdk:"renew_request_body"`
@@ -81,6 +88,7 @@ type CurlEphemeralModel struct {
 	RenewTimeout           types.Int64  `tfsdk:"renew_timeout"`
 	RenewResponse          types.String `tfsdk:"renew_response"`
 	RenewResponseCodes     types.List   `tfsdk:"renew_response_codes"`
+	OnRenewFailure         types.String `tfsdk:"on_renew_failure"`
 
 	SkipClose types.Bool `tfsdk:"skip_close"`
 
@@ -100,6 +108,25 @@ type CurlEphemeralModel struct {
 	CloseTimeout           types.Int64  `tfsdk:"close_timeout"`
 	CloseResponse          types.String `tfsdk:"close_response"`
 	CloseResponseCodes     types.List   `tfsdk:"close_response_codes"`
+
+	Assert      types.List `tfsdk:"assert"`
+	RenewAssert types.List `tfsdk:"renew_assert"`
+	CloseAssert types.List `tfsdk:"close_assert"`
+
+	RetryPolicy      types.Object `tfsdk:"retry_policy"`
+	RenewRetryPolicy types.Object `tfsdk:"renew_retry_policy"`
+	CloseRetryPolicy types.Object `tfsdk:"close_retry_policy"`
+
+	Multipart types.Object `tfsdk:"multipart"`
+
+	FormBody            types.Map `tfsdk:"form_body"`
+	FormBodyValues      types.Map `tfsdk:"form_body_values"`
+	RenewFormBody       types.Map `tfsdk:"renew_form_body"`
+	RenewFormBodyValues types.Map `tfsdk:"renew_form_body_values"`
+	CloseFormBody       types.Map `tfsdk:"close_form_body"`
+	CloseFormBodyValues types.Map `tfsdk:"close_form_body_values"`
+
+	Timeouts types.Object `tfsdk:"timeouts"`
 }
 
 func (e *EphemeralCurlResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
@@ -128,6 +155,16 @@ func (e *EphemeralCurlResource) Schema(ctx context.Context, req ephemeral.Schema
 				Optional:            true,
 				MarkdownDescription: "A request body to attach to the API call",
 			},
+			"form_body": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyDescription, "open", "`request_body` and `multipart`"),
+			},
+			"form_body_values": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyValuesDescription, "open", "form_body"),
+			},
 			"headers": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
@@ -181,8 +218,9 @@ func (e *EphemeralCurlResource) Schema(ctx context.Context, req ephemeral.Schema
 			},
 			"response_codes": schema.ListAttribute{
 				Required:            true,
-				MarkdownDescription: "A list of expected response codes",
+				MarkdownDescription: "A list of expected response codes. Supports exact codes (`200`), classes (`2xx`), inclusive ranges (`200-299`) and negations (`!409`).",
 				ElementType:         types.StringType,
+				Validators:          validResponseCodes(),
 			},
 			"status_code": schema.StringAttribute{
 				Computed:            true,
@@ -201,7 +239,8 @@ func (e *EphemeralCurlResource) Schema(ctx context.Context, req ephemeral.Schema
 			},
 			"renew_url": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Api endpoint to call",
+				MarkdownDescription: "Api endpoint to call. " + ephemeralTemplateDescription,
+				Validators:          []validator.String{templateValidator{}},
 			},
 			"renew_method": schema.StringAttribute{
 				Optional:            true,
@@ -209,17 +248,32 @@ func (e *EphemeralCurlResource) Schema(ctx context.Context, req ephemeral.Schema
 			},
 			"renew_request_body": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "A request body to attach to the API call",
+				MarkdownDescription: "A request body to attach to the API call. " + ephemeralTemplateDescription,
+				Validators:          []validator.String{templateValidator{}},
+			},
+			"renew_form_body": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyDescription, "renew", "`renew_request_body`") + " Values support the same templates as `renew_url`.",
+				Validators:          []validator.Map{mapvalidator.ValueStringsAre(templateValidator{})},
+			},
+			"renew_form_body_values": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyValuesDescription, "renew", "renew_form_body") + " Values support the same templates as `renew_url`.",
+				Validators:          []validator.Map{mapvalidator.ValueListsAre(listvalidator.ValueStringsAre(templateValidator{}))},
 			},
 			"renew_headers": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
-				MarkdownDescription: "Map of headers to attach to the API call",
+				MarkdownDescription: "Map of headers to attach to the API call. Values support the same templates as `renew_url`.",
+				Validators:          []validator.Map{mapvalidator.ValueStringsAre(templateValidator{})},
 			},
 			"renew_request_parameters": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
-				MarkdownDescription: "Map of parameters to attach to the API call",
+				MarkdownDescription: "Map of parameters to attach to the API call. Values support the same templates as `renew_url`.",
+				Validators:          []validator.Map{mapvalidator.ValueStringsAre(templateValidator{})},
 			},
 			"renew_request_url_string": schema.StringAttribute{
 				Computed:            true,
@@ -247,11 +301,11 @@ func (e *EphemeralCurlResource) Schema(ctx context.Context, req ephemeral.Schema
 			},
 			"renew_retry_interval": schema.Int64Attribute{
 				Optional:            true,
-				MarkdownDescription: "Interval between each attempt",
+				MarkdownDescription: "Interval between each attempt. Defaults to `retry_interval`",
 			},
 			"renew_max_retry": schema.Int64Attribute{
 				Optional:            true,
-				MarkdownDescription: "Maximum number of tries until it is marked as failed",
+				MarkdownDescription: "Maximum number of tries until it is marked as failed. Defaults to `max_retry`",
 			},
 			"renew_timeout": schema.Int64Attribute{
 				Optional:            true,
@@ -264,8 +318,17 @@ func (e *EphemeralCurlResource) Schema(ctx context.Context, req ephemeral.Schema
 			},
 			"renew_response_codes": schema.ListAttribute{
 				Optional:            true,
-				MarkdownDescription: "A list of expected response codes",
+				MarkdownDescription: "A list of expected response codes. Supports exact codes (`200`), classes (`2xx`), inclusive ranges (`200-299`) and negations (`!409`).",
 				ElementType:         types.StringType,
+				Validators:          validResponseCodes(),
+			},
+			"on_renew_failure": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "What to do when the renew request fails once its retries are exhausted: `error` fails the run, `reopen` sends the open request again and renews from its response, `ignore` adds a warning and renews again after `renew_interval`, which must then be set. Values already read from the ephemeral resource are not updated by `reopen`. Defaults to `error`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(renewFailureError, renewFailureReopen, renewFailureIgnore),
+					renewFailureIgnoreValidator{},
+				},
 			},
 			"skip_close": schema.BoolAttribute{
 				Optional:            true,
@@ -275,7 +338,8 @@ func (e *EphemeralCurlResource) Schema(ctx context.Context, req ephemeral.Schema
 
 			"close_url": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Api endpoint to call",
+				MarkdownDescription: "Api endpoint to call. " + ephemeralTemplateDescription,
+				Validators:          []validator.String{templateValidator{}},
 			},
 			"close_method": schema.StringAttribute{
 				Optional:            true,
@@ -283,17 +347,32 @@ func (e *EphemeralCurlResource) Schema(ctx context.Context, req ephemeral.Schema
 			},
 			"close_request_body": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "A request body to attach to the API call",
+				MarkdownDescription: "A request body to attach to the API call. " + ephemeralTemplateDescription,
+				Validators:          []validator.String{templateValidator{}},
+			},
+			"close_form_body": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyDescription, "close", "`close_request_body`") + " Values support the same templates as `close_url`.",
+				Validators:          []validator.Map{mapvalidator.ValueStringsAre(templateValidator{})},
+			},
+			"close_form_body_values": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyValuesDescription, "close", "close_form_body") + " Values support the same templates as `close_url`.",
+				Validators:          []validator.Map{mapvalidator.ValueListsAre(listvalidator.ValueStringsAre(templateValidator{}))},
 			},
 			"close_headers": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
-				MarkdownDescription: "Map of headers to attach to the API call",
+				MarkdownDescription: "Map of headers to attach to the API call. Values support the same templates as `close_url`.",
+				Validators:          []validator.Map{mapvalidator.ValueStringsAre(templateValidator{})},
 			},
 			"close_request_parameters": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
-				MarkdownDescription: "Map of parameters to attach to the API call",
+				MarkdownDescription: "Map of parameters to attach to the API call. Values support the same templates as `close_url`.",
+				Validators:          []validator.Map{mapvalidator.ValueStringsAre(templateValidator{})},
 			},
 			"close_request_url_string": schema.StringAttribute{
 				Computed:            true,
@@ -338,11 +417,44 @@ func (e *EphemeralCurlResource) Schema(ctx context.Context, req ephemeral.Schema
 			},
 			"close_response_codes": schema.ListAttribute{
 				Optional:            true,
-				MarkdownDescription: "A list of expected response codes",
+				MarkdownDescription: "A list of expected response codes. Supports exact codes (`200`), classes (`2xx`), inclusive ranges (`200-299`) and negations (`!409`).",
 				ElementType:         types.StringType,
+				Validators:          validResponseCodes(),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"assert":             assertionEphemeralBlock("open"),
+			"renew_assert":       assertionEphemeralBlock("renew"),
+			"close_assert":       assertionEphemeralBlock("close"),
+			"retry_policy":       retryPolicyEphemeralBlock("open"),
+			"renew_retry_policy": retryPolicyEphemeralBlock("renew"),
+			"close_retry_policy": retryPolicyEphemeralBlock("close"),
+			"timeouts":           ephemeralTimeoutsBlock(),
+			"renew_at_from":      renewAtFromEphemeralBlock(),
+			"multipart":          multipartEphemeralBlock(),
+		},
+	}
+}
+
+func (e *EphemeralCurlResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
 	}
+
+	e.retryPolicy = data.RetryPolicy
+	e.logging = data.Logging
 }
 
 func (e *EphemeralCurlResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
@@ -355,6 +467,14 @@ func (e *EphemeralCurlResource) Open(ctx context.Context, req ephemeral.OpenRequ
 		return
 	}
 
+	operationTimeouts, diags := ephemeralTimeoutsFromObject(ctx, data.Timeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, operationTimeouts.Open)
+	defer cancel()
+
 	if !data.SkipRenew.IsNull() && !data.SkipRenew.ValueBool() {
 		if data.RenewUrl.IsNull() || data.RenewMethod.IsNull() || data.RenewResponseCodes.IsNull() {
 			resp.Diagnostics.AddError(
@@ -409,9 +529,7 @@ func (e *EphemeralCurlResource) Open(ctx context.Context, req ephemeral.OpenRequ
 
 	} else {
 		// Use default non-TLS client.
-		client = &http.Client{
-			Timeout: 30 * time.Second,
-		}
+		client = &http.Client{}
 	}
 
 	reqBody := []byte(data.RequestBody.ValueString())
@@ -442,1098 +560,295 @@ func (e *EphemeralCurlResource) Open(ctx context.Context, req ephemeral.OpenRequ
 	}
 	data.RequestUrlString = types.StringValue(request.URL.String())
 
-	tflog.Debug(ctx, fmt.Sprintf("Epehemeral Rescource Open API Call: \nURL: %s\nHeaders: %s\nMethod: %s\nRequest Body: %s\n", request.URL.String(), request.Header, request.Method, request.Body))
-
-	timeout := 10 * time.Second
-	if !data.Timeout.IsNull() {
-		timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
-	}
-
-	var body []byte
-	var bodyString string
-	var statusCode int
-	retryCount := 0
-
-	for {
-		ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
-
-		response, err := client.Do(request.WithContext(ctxWithTimeout))
-		if err != nil {
-			if retryCount < int(data.MaxRetry.ValueInt64()) {
-				retryCount++
-				time.Sleep(time.Duration(data.RetryInterval.ValueInt64()) * time.Second)
-				continue
-			}
-			resp.Diagnostics.AddError("Request Failed", err.Error())
-			return
-		}
-
-		defer func(Body io.ReadCloser) {
-			err := Body.Close()
-			if err != nil {
-				return
-			}
-		}(response.Body)
-		body, _ = io.ReadAll(response.Body)
-		statusCode = response.StatusCode
-
-		bodyString = string(body)
-		if bodyString == "" {
-			bodyString = "{}"
-		}
-
-		var responseCodes []string
-		for _, v := range data.ResponseCodes.Elements() {
-			if strVal, ok := v.(types.String); ok {
-				responseCodes = append(responseCodes, strVal.ValueString())
-			}
-		}
-
-		if responseCodeChecker(responseCodes, strconv.Itoa(statusCode)) {
-			break
-		}
-
-		if retryCount < int(data.MaxRetry.ValueInt64()) {
-			retryCount++
-			time.Sleep(time.Duration(data.RetryInterval.ValueInt64()) * time.Second)
-		} else {
-			resp.Diagnostics.AddError("Unexpected Response Code", fmt.Sprintf("Received status code: %d", statusCode))
-			return
-		}
+	parts, diags := setMultipartBody(ctx, data.Multipart, request)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	data.RequestUrlString = types.StringValue(request.URL.String())
-	data.Response = types.StringValue(bodyString)
-	data.StatusCode = types.StringValue(strconv.Itoa(statusCode))
-
-	tflog.Debug(ctx, fmt.Sprintf("renew parameters in Open() is set to %v", convertMap(data.RenewRequestParameters)))
-
-	if !data.SkipRenew.ValueBool() {
-		renewDuration := time.Duration(data.RenewInterval.ValueInt64()) * time.Second
-		resp.RenewAt = time.Now().Add(renewDuration)
-		tflog.Debug(ctx, fmt.Sprintf("Setting RenewAt to: %s (in %d seconds)", resp.RenewAt, renewDuration/time.Second))
+	form := formValues(data.FormBody, data.FormBodyValues)
+	loggedBody := data.RequestBody.ValueString()
+	if parts != nil {
+		loggedBody = parts.describe()
 	}
-
-	privateData := map[string]interface{}{
-		"Id":                data.Id.ValueString(),
-		"Name":              data.Name.ValueString(),
-		"Url":               data.Url.ValueString(),
-		"Method":            data.Method.ValueString(),
-		"RequestBody":       data.RequestBody.ValueString(),
-		"Headers":           convertMap(data.Headers),
-		"RequestParameters": convertMap(data.RequestParameters),
-		"RequestUrlString":  data.RequestUrlString.ValueString(),
-		"CertFile":          data.CertFile.ValueString(),
-		"KeyFile":           data.KeyFile.ValueString(),
-		"CaCertFile":        data.CaCertFile.ValueString(),
-		"CaCertDirectory":   data.CaCertDirectory.ValueString(),
-		"SkipTlsVerify":     data.SkipTlsVerify.ValueBool(),
-		"RetryInterval":     data.RetryInterval.ValueInt64(),
-		"MaxRetry":          data.MaxRetry.ValueInt64(),
-		"Timeout":           data.Timeout.ValueInt64(),
-		"StatusCode":        data.StatusCode.ValueString(),
-		"Response_codes":    data.ResponseCodes.Elements(),
-		"Response":          data.Response.ValueString(),
-
-		"SkipRenew":              data.SkipRenew.ValueBool(),
-		"RenewUrl":               data.RenewUrl.ValueString(),
-		"RenewMethod":            data.RenewMethod.ValueString(),
-		"RenewHeaders":           convertMap(data.RenewHeaders),
-		"RenewRequestParameters": convertMap(data.RenewRequestParameters),
-		"RenewRequestBody":       data.RenewRequestBody.ValueString(),
-		"RenewRequestUrlString":  data.RenewRequestUrlString.ValueString(),
-		"RenewCertFile":          data.RenewCertFile.ValueString(),
-		"RenewKeyFile":           data.RenewKeyFile.ValueString(),
-		"RenewCaCertFile":        data.RenewCaCertFile.ValueString(),
-		"RenewCaCertDirectory":   data.RenewCaCertDirectory.ValueString(),
-		"RenewSkipTlsVerify":     data.RenewSkipTlsVerify.ValueBool(),
-		"RenewRetryInterval":     data.RenewRetryInterval.ValueInt64(),
-		"RenewMaxRetry":          data.RenewMaxRetry.ValueInt64(),
-		"RenewTimeout":           data.RenewTimeout.ValueInt64(),
-		"RenewResponse_codes":    data.RenewResponseCodes.Elements(),
-		"RenewResponse":          data.RenewResponse.ValueString(),
-		"RenewInterval":          data.RenewInterval.ValueInt64(),
-
-		"SkipClose":              data.SkipClose.ValueBool(),
-		"CloseUrl":               data.CloseUrl.ValueString(),
-		"CloseMethod":            data.CloseMethod.ValueString(),
-		"CloseHeaders":           convertMap(data.CloseHeaders),
-		"CloseRequestParameters": convertMap(data.CloseRequestParameters),
-		"CloseRequestBody":       data.CloseRequestBody.ValueString(),
-		"CloseRequestUrlString":  data.CloseRequestUrlString.ValueString(),
-		"CloseCertFile":          data.CloseCertFile.ValueString(),
-		"CloseKeyFile":           data.CloseKeyFile.ValueString(),
-		"CloseCaCertFile":        data.CloseCaCertFile.ValueString(),
-		"CloseCaCertDirectory":   data.CloseCaCertDirectory.ValueString(),
-		"CloseSkipTlsVerify":     data.CloseSkipTlsVerify.ValueBool(),
-		"CloseRetryInterval":     data.CloseRetryInterval.ValueInt64(),
-		"CloseMaxRetry":          data.CloseMaxRetry.ValueInt64(),
-		"CloseTimeout":           data.CloseTimeout.ValueInt64(),
-		"CloseResponseCodes":     data.CloseResponseCodes.Elements(),
-		"CloseResponse":          data.CloseResponse.ValueString(),
+	if form != nil {
+		loggedBody = setFormBody(request, form)
 	}
+	ctx = e.logging.withMasking(ctx, request, loggedBody)
+	e.logging.logRequest(ctx, "Open", request, loggedBody)
 
-	var closeResponseCodesList []string
-	for _, v := range data.CloseResponseCodes.Elements() {
-		if strVal, ok := v.(types.String); ok {
-			closeResponseCodesList = append(closeResponseCodesList, strVal.ValueString())
-		}
+	timeout := 10 * time.Second
+	if !data.Timeout.IsNull() {
+		timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
 	}
 
-	var renewResponseCodesList []string
-	for _, v := range data.RenewResponseCodes.Elements() {
+	var responseCodes []string
+	for _, v := range data.ResponseCodes.Elements() {
 		if strVal, ok := v.(types.String); ok {
-			renewResponseCodesList = append(renewResponseCodesList, strVal.ValueString())
+			responseCodes = append(responseCodes, strVal.ValueString())
 		}
 	}
 
-	privateData["CloseResponseCodes"] = closeResponseCodesList
-	privateData["RenewResponseCodes"] = renewResponseCodesList
-
-	privateBytes, diags := json.Marshal(privateData)
-
-	if diags != nil {
-		resp.Diagnostics.AddError("Error marshaling data", fmt.Sprintf("%s", diags))
-		return
-	}
-
-	tflog.Debug(ctx, fmt.Sprintf("Storing privateData: %s", string(privateBytes)))
-
-	resp.Private.SetKey(ctx, "response", privateBytes)
-	resp.Result.Set(ctx, privateData)
-
-	// Save data into ephemeral result data.
-	resp.Diagnostics.Append(resp.Result.Set(ctx, data)...)
-}
-
-func (e *EphemeralCurlResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
-	tflog.Debug(ctx, "Running Renew()")
-	privateBytes, diags := req.Private.GetKey(ctx, "response")
+	assertions, diags := assertionsFromList(ctx, data.Assert)
+	resp.Diagnostics.Append(diags...)
+	renewAssertions, diags := assertionsFromList(ctx, data.RenewAssert)
+	resp.Diagnostics.Append(diags...)
+	closeAssertions, diags := assertionsFromList(ctx, data.CloseAssert)
+	resp.Diagnostics.Append(diags...)
+	policy, diags := retryPolicyFromObject(ctx, data.RetryPolicy, e.retryPolicy)
+	resp.Diagnostics.Append(diags...)
+	renewPolicy, diags := retryPolicyFromObject(ctx, data.RenewRetryPolicy, policy)
+	resp.Diagnostics.Append(diags...)
+	closePolicy, diags := retryPolicyFromObject(ctx, data.CloseRetryPolicy, e.retryPolicy)
+	resp.Diagnostics.Append(diags...)
+	schedule, diags := renewScheduleFromObject(ctx, data.RenewAtFrom)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
-		tflog.Error(ctx, "Error getting private data at renew step")
 		return
 	}
 
-	var privateMap map[string]interface{}
-	err := json.Unmarshal(privateBytes, &privateMap)
+	result, err := executeRequest(ctx, client, request, requestOptions{
+		Operation:     "Open",
+		MaxRetry:      int(data.MaxRetry.ValueInt64()),
+		RetryInterval: time.Duration(data.RetryInterval.ValueInt64()) * time.Second,
+		Timeout:       timeout,
+		ResponseCodes: responseCodes,
+		Assertions:    assertions,
+		RetryPolicy:   policy,
+		Logging:       e.logging,
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("Error unmarshaling response", fmt.Sprintf("%s", err))
-		return
-	}
-
-	renewMethod, ok := privateMap["RenewMethod"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "RenewMethod is not a string")
-		return
-	}
-
-	renewUrl, ok := privateMap["RenewUrl"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "RenewUrl is not a string")
-		return
-	}
-
-	skipRenew, ok := privateMap["SkipRenew"].(bool)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "SkipRenew is not a boolean")
-		return
-	}
-
-	var renewResponseCodes []string
-
-	// Check if the key exists AND is not nil before asserting type.
-	if rawList, exists := privateMap["RenewResponseCodes"]; exists && rawList != nil {
-		switch v := rawList.(type) {
-		case []interface{}:
-			for _, item := range v {
-				if strVal, ok := item.(string); ok {
-					renewResponseCodes = append(renewResponseCodes, strVal)
-				} else {
-					resp.Diagnostics.AddError("Type Assertion Error", "RenewResponseCodes contains non-string elements")
-					return
-				}
-			}
-		case []string:
-			renewResponseCodes = v
-		default:
-			resp.Diagnostics.AddError(
-				"Type Assertion Error",
-				fmt.Sprintf("RenewResponseCodes has unexpected type: %T", rawList),
-			)
-			return
-		}
-	} else {
-		// If attribute is missing, default to an empty slice without throwing an error.
-		renewResponseCodes = []string{}
-	}
-
-	// Convert to Terraform ListValue.
-	renewResponseCodesList, diags := types.ListValue(
-		types.StringType,
-		convertStringSliceToTFValues(renewResponseCodes),
-	)
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
-		return
-	}
-
-	renewRequestBody, ok := privateMap["RenewRequestBody"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "RenewRequestBody is not a string")
-		return
-	}
-
-	renewHeaders := make(map[string]string) // Default to empty map
-
-	if rawRenewHeaders, exists := privateMap["RenewHeaders"]; exists && rawRenewHeaders != nil {
-		if rawHeaders, ok := rawRenewHeaders.(map[string]interface{}); ok {
-			for key, value := range rawHeaders {
-				if strValue, ok := value.(string); ok {
-					renewHeaders[key] = strValue
-				} else {
-					resp.Diagnostics.AddError("Type Assertion Error", fmt.Sprintf("RenewHeaders[%s] is not a string", key))
-					return
-				}
-			}
-		} else {
-			resp.Diagnostics.AddError("Type Assertion Error", "RenewHeaders is not a map[string]interface{}")
-			return
-		}
-	}
-
-	// Convert to Terraform types.Map.
-	renewHeadersTF, diags := types.MapValue(types.StringType, convertStringMapToTFValues(renewHeaders))
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
-		return
-	}
-
-	renewParameters := make(map[string]string) // Default to empty map
-	tflog.Debug(ctx, fmt.Sprintf("Private Map renew parameters: %v", privateMap["RenewRequestParameters"]))
-	if rawRenewParameters, exists := privateMap["RenewRequestParameters"]; exists && rawRenewParameters != nil {
-		if rawParameters, ok := rawRenewParameters.(map[string]interface{}); ok {
-			for key, value := range rawParameters {
-				if strValue, ok := value.(string); ok {
-					tflog.Debug(ctx, fmt.Sprintf("Adding %s to renew request parameters\n", renewParameters[key]))
-					renewParameters[key] = strValue
-				} else {
-					resp.Diagnostics.AddError("Type Assertion Error", fmt.Sprintf("RenewRequestParameters[%s] is not a string", key))
-					return
-				}
-			}
-		} else {
-			resp.Diagnostics.AddError("Type Assertion Error", "RenewRequestParameters is not a map[string]interface{}")
-			return
-		}
-	}
-
-	// Convert to Terraform types.Map.
-	renewParametersTF, diags := types.MapValue(types.StringType, convertStringMapToTFValues(renewParameters))
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
-		return
-	}
-
-	renewRequestUrlString, ok := privateMap["RenewRequestUrlString"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " RenewRequestUrlString is not a string")
-		return
-	}
-
-	renewCertFile, ok := privateMap["RenewCertFile"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " RenewCertFile is not a string")
-		return
-	}
-
-	renewKeyFile, ok := privateMap["RenewKeyFile"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " RenewKeyFile is not a string")
-		return
-	}
-
-	renewCaCertFile, ok := privateMap["RenewCaCertFile"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " RenewCaCertFile is not a string")
-		return
-	}
-
-	renewCaCertDirectory, ok := privateMap["RenewCaCertDirectory"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " RenewCaCertDirectory is not a string")
-		return
-	}
-
-	renewSkipTlsVerify, ok := privateMap["RenewSkipTlsVerify"].(bool)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " RenewSkipTlsVerify is not a bool")
-		return
-	}
-
-	renewRetryIntervalFloat, ok := privateMap["RenewRetryInterval"].(float64)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "RenewRetryInterval is not a float64 (expected int64)")
-		return
-	}
-	renewRetryInterval := int64(renewRetryIntervalFloat)
-
-	renewMaxRetryFloat, ok := privateMap["RenewMaxRetry"].(float64)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "RenewMaxRetry is not a float64 (expected int64)")
-		return
-	}
-	renewMaxRetry := int64(renewMaxRetryFloat)
-
-	renewTimeoutFloat, ok := privateMap["RenewTimeout"].(float64)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "RenewTimeout is not a float64 (expected int64)")
-		return
-	}
-	renewTimeout := int64(renewTimeoutFloat)
-
-	renewResponse, ok := privateMap["RenewResponse"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " RenewResponse is not a string")
-		return
-	}
-
-	closeMethod, ok := privateMap["CloseMethod"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "CloseMethod is not a string")
-		return
-	}
-
-	closeUrl, ok := privateMap["CloseUrl"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "CloseUrl is not a string")
-		return
-	}
-
-	skipClose, ok := privateMap["SkipClose"].(bool)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "SkipClose is not a boolean")
-		return
-	}
-
-	var closeResponseCodes []string
-
-	// Check if the key exists AND is not nil before asserting type.
-	if rawList, exists := privateMap["CloseResponseCodes"]; exists && rawList != nil {
-		switch v := rawList.(type) {
-		case []interface{}:
-			for _, item := range v {
-				if strVal, ok := item.(string); ok {
-					closeResponseCodes = append(closeResponseCodes, strVal)
-				} else {
-					resp.Diagnostics.AddError("Type Assertion Error", "CloseResponseCodes contains non-string elements")
-					return
-				}
-			}
-		case []string:
-			closeResponseCodes = v
-		default:
-			resp.Diagnostics.AddError(
-				"Type Assertion Error",
-				fmt.Sprintf("CloseResponseCodes has unexpected type: %T", rawList),
-			)
-			return
-		}
-	} else {
-		// If attribute is missing, default to an empty slice without throwing an error.
-		closeResponseCodes = []string{}
-	}
-
-	// Convert to Terraform ListValue.
-	closeResponseCodesList, diags := types.ListValue(
-		types.StringType,
-		convertStringSliceToTFValues(closeResponseCodes),
-	)
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
-		return
-	}
-
-	closeRequestBody, ok := privateMap["CloseRequestBody"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "CloseRequestBody is not a string")
+		addRequestError(&resp.Diagnostics, err)
 		return
 	}
 
-	closeHeaders := make(map[string]string)
-	if rawCloseHeaders, exists := privateMap["CloseHeaders"]; exists && rawCloseHeaders != nil {
-		if rawHeaders, ok := rawCloseHeaders.(map[string]interface{}); ok {
-			for key, value := range rawHeaders {
-				if strValue, ok := value.(string); ok {
-					closeHeaders[key] = strValue
-				} else {
-					resp.Diagnostics.AddError("Type Assertion Error", fmt.Sprintf("CloseHeaders[%s] is not a string", key))
-					return
-				}
-			}
-		} else {
-			resp.Diagnostics.AddError("Type Assertion Error", "CloseHeaders is not a map[string]interface{}")
-			return
-		}
-	}
-
-	// Convert to Terraform types.Map.
-	closeHeadersTF, diags := types.MapValue(types.StringType, convertStringMapToTFValues(closeHeaders))
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
-		return
+	statusCode := result.StatusCode
+	bodyString := string(result.Body)
+	if bodyString == "" {
+		bodyString = "{}"
 	}
 
-	closeParameters := make(map[string]string) // Default to empty map
-
-	if rawCloseParameters, exists := privateMap["CloseRequestParameters"]; exists && rawCloseParameters != nil {
-		if rawParameters, ok := rawCloseParameters.(map[string]interface{}); ok {
-			for key, value := range rawParameters {
-				if strValue, ok := value.(string); ok {
-					closeParameters[key] = strValue
-				} else {
-					resp.Diagnostics.AddError("Type Assertion Error", fmt.Sprintf("CloseRequestParameters[%s] is not a string", key))
-					return
-				}
-			}
-		} else {
-			resp.Diagnostics.AddError("Type Assertion Error", "CloseRequestParameters is not a map[string]interface{}")
-			return
-		}
-	}
+	data.RequestUrlString = types.StringValue(request.URL.String())
+	data.Response = types.StringValue(bodyString)
+	data.StatusCode = types.StringValue(strconv.Itoa(statusCode))
 
-	// Convert to Terraform types.Map.
-	closeParametersTF, diags := types.MapValue(types.StringType, convertStringMapToTFValues(closeParameters))
-	if diags.HasError() {
+	if !data.SkipRenew.ValueBool() {
+		now := time.Now()
+		resp.RenewAt, diags = nextRenewAt(schedule, data.RenewInterval.ValueInt64(), result.Body, now)
 		resp.Diagnostics.Append(diags...)
-		return
-	}
-
-	closeRequestUrlString, ok := privateMap["CloseRequestUrlString"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseRequestUrlString is not a string")
-		return
-	}
-
-	closeCertFile, ok := privateMap["CloseCertFile"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseCertFile is not a string")
-		return
-	}
-
-	closeKeyFile, ok := privateMap["CloseKeyFile"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseKeyFile is not a string")
-		return
-	}
-
-	closeCaCertFile, ok := privateMap["CloseCaCertFile"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseCaCertFile is not a string")
-		return
-	}
-
-	closeCaCertDirectory, ok := privateMap["CloseCaCertDirectory"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseCaCertDirectory is not a string")
-		return
+		tflog.Debug(ctx, fmt.Sprintf("Setting RenewAt to: %s (in %d seconds)", resp.RenewAt, resp.RenewAt.Sub(now)/time.Second))
 	}
 
-	closeSkipTlsVerify, ok := privateMap["CloseSkipTlsVerify"].(bool)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseSkipTlsVerify is not a bool")
-		return
+	privateData := ephemeralPrivateData{
+		Version:        ephemeralPrivateDataVersion,
+		RenewInterval:  data.RenewInterval.ValueInt64(),
+		RenewSchedule:  schedule,
+		OnRenewFailure: data.OnRenewFailure.ValueString(),
 	}
+	if !data.SkipRenew.ValueBool() {
+		// Renew attempts are retried like the open request unless configured
+		// otherwise.
+		renewMaxRetry, renewRetryInterval := data.MaxRetry, data.RetryInterval
+		if !data.RenewMaxRetry.IsNull() {
+			renewMaxRetry, renewRetryInterval = data.RenewMaxRetry, data.RenewRetryInterval
+		}
+		privateData.Renew = &ephemeralRequest{
+			Url:              data.RenewUrl.ValueString(),
+			Method:           data.RenewMethod.ValueString(),
+			ResponseCodes:    stringElements(data.RenewResponseCodes),
+			TLS:              ephemeralTlsConfig(data.RenewCertFile, data.RenewKeyFile, data.RenewCaCertFile, data.RenewCaCertDirectory, data.RenewSkipTlsVerify),
+			MaxRetry:         renewMaxRetry.ValueInt64(),
+			RetryInterval:    renewRetryInterval.ValueInt64(),
+			Timeout:          data.RenewTimeout.ValueInt64(),
+			OperationTimeout: operationTimeouts.Renew,
+			Assertions:       renewAssertions,
+			RetryPolicy:      renewPolicy,
+			Secrets: ephemeralRequestSecrets{
+				Headers:    convertMap(data.RenewHeaders),
+				Parameters: convertMap(data.RenewRequestParameters),
+				Body:       data.RenewRequestBody.ValueString(),
+				Form:       formValues(data.RenewFormBody, data.RenewFormBodyValues),
+			},
+		}
+	}
+	if !data.SkipClose.ValueBool() {
+		privateData.Close = &ephemeralRequest{
+			Url:              data.CloseUrl.ValueString(),
+			Method:           data.CloseMethod.ValueString(),
+			ResponseCodes:    stringElements(data.CloseResponseCodes),
+			TLS:              ephemeralTlsConfig(data.CloseCertFile, data.CloseKeyFile, data.CloseCaCertFile, data.CloseCaCertDirectory, data.CloseSkipTlsVerify),
+			MaxRetry:         data.CloseMaxRetry.ValueInt64(),
+			RetryInterval:    data.CloseRetryInterval.ValueInt64(),
+			Timeout:          data.CloseTimeout.ValueInt64(),
+			OperationTimeout: operationTimeouts.Close,
+			Assertions:       closeAssertions,
+			RetryPolicy:      closePolicy,
+			Secrets: ephemeralRequestSecrets{
+				Headers:    convertMap(data.CloseHeaders),
+				Parameters: convertMap(data.CloseRequestParameters),
+				Body:       data.CloseRequestBody.ValueString(),
+				Form:       formValues(data.CloseFormBody, data.CloseFormBodyValues),
+			},
+		}
+	}
+
+	if !data.SkipRenew.ValueBool() && data.OnRenewFailure.ValueString() == renewFailureReopen {
+		privateData.Open = &ephemeralRequest{
+			Url:           data.Url.ValueString(),
+			Method:        data.Method.ValueString(),
+			ResponseCodes: responseCodes,
+			TLS:           ephemeralTlsConfig(data.CertFile, data.KeyFile, data.CaCertFile, data.CaCertDirectory, data.SkipTlsVerify),
+			MaxRetry:      data.MaxRetry.ValueInt64(),
+			RetryInterval: data.RetryInterval.ValueInt64(),
+			Timeout:       int64(timeout / time.Second),
+			Assertions:    assertions,
+			RetryPolicy:   policy,
+			Secrets: ephemeralRequestSecrets{
+				Headers:    convertMap(data.Headers),
+				Parameters: convertMap(data.RequestParameters),
+				Body:       data.RequestBody.ValueString(),
+				Multipart:  parts,
+				Form:       form,
+			},
+		}
+	}
+
+	if privateData.Renew.usesTemplates() || privateData.Close.usesTemplates() {
+		openResponse := newEphemeralResponse(request, result)
+		privateData.Responses = &ephemeralResponses{Open: openResponse, Latest: openResponse}
+	}
+
+	resp.Diagnostics.Append(privateData.save(ctx, resp.Private)...)
 
-	closeRetryIntervalFloat, ok := privateMap["CloseRetryInterval"].(float64)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "CloseRetryInterval is not a float64 (expected int64)")
-		return
-	}
-
-	closeRetryInterval := int64(closeRetryIntervalFloat)
-
-	closeMaxRetryFloat, ok := privateMap["CloseMaxRetry"].(float64)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "CloseMaxRetry is not a float64 (expected int64)")
-		return
-	}
-	closeMaxRetry := int64(closeMaxRetryFloat)
-
-	closeTimeoutFloat, ok := privateMap["CloseTimeout"].(float64)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "CloseTimeout is not a float64 (expected int64)")
-		return
-	}
-	closeTimeout := int64(closeTimeoutFloat)
+	// Save data into ephemeral result data.
+	resp.Diagnostics.Append(resp.Result.Set(ctx, data)...)
+}
 
-	closeResponse, ok := privateMap["CloseResponse"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseResponse is not a string")
+func (e *EphemeralCurlResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+	tflog.Debug(ctx, "Running Renew()")
+	privateData, diags := getEphemeralPrivateData(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	privateData := CurlEphemeralModel{
-		RenewMethod:            types.StringValue(renewMethod),
-		RenewUrl:               types.StringValue(renewUrl),
-		SkipRenew:              types.BoolValue(skipRenew),
-		RenewResponseCodes:     renewResponseCodesList,
-		RenewHeaders:           renewHeadersTF,
-		RenewRequestParameters: renewParametersTF,
-		RenewRequestBody:       types.StringValue(renewRequestBody),
-		RenewRequestUrlString:  types.StringValue(renewRequestUrlString),
-		RenewCertFile:          types.StringValue(renewCertFile),
-		RenewKeyFile:           types.StringValue(renewKeyFile),
-		RenewCaCertFile:        types.StringValue(renewCaCertFile),
-		RenewCaCertDirectory:   types.StringValue(renewCaCertDirectory),
-		RenewSkipTlsVerify:     types.BoolValue(renewSkipTlsVerify),
-		RenewRetryInterval:     types.Int64Value(renewRetryInterval),
-		RenewMaxRetry:          types.Int64Value(renewMaxRetry),
-		RenewTimeout:           types.Int64Value(renewTimeout),
-		RenewResponse:          types.StringValue(renewResponse),
-
-		CloseMethod:            types.StringValue(closeMethod),
-		CloseUrl:               types.StringValue(closeUrl),
-		SkipClose:              types.BoolValue(skipClose),
-		CloseResponseCodes:     closeResponseCodesList,
-		CloseHeaders:           closeHeadersTF,
-		CloseRequestParameters: closeParametersTF,
-		CloseRequestBody:       types.StringValue(closeRequestBody),
-		CloseRequestUrlString:  types.StringValue(closeRequestUrlString),
-		CloseCertFile:          types.StringValue(closeCertFile),
-		CloseKeyFile:           types.StringValue(closeKeyFile),
-		CloseCaCertFile:        types.StringValue(closeCaCertFile),
-		CloseCaCertDirectory:   types.StringValue(closeCaCertDirectory),
-		CloseSkipTlsVerify:     types.BoolValue(closeSkipTlsVerify),
-		CloseRetryInterval:     types.Int64Value(closeRetryInterval),
-		CloseMaxRetry:          types.Int64Value(closeMaxRetry),
-		CloseTimeout:           types.Int64Value(closeTimeout),
-		CloseResponse:          types.StringValue(closeResponse),
-	}
-
-	if privateData.SkipRenew.IsUnknown() {
-		tflog.Debug(ctx, "`skip_renew` not set. using default value `true`")
-		privateData.SkipRenew = types.BoolValue(true)
-	}
-
-	if privateData.SkipRenew.ValueBool() {
+	renew := privateData.Renew
+	if renew == nil {
 		tflog.Debug(ctx, "`skip_renew` set to `true`. Skipping renew call.")
 		return
 	}
-
-	if !privateData.SkipRenew.ValueBool() {
-		if privateData.RenewUrl.ValueString() == "" || privateData.RenewMethod.ValueString() == "" || privateData.RenewResponseCodes.IsNull() {
-			resp.Diagnostics.AddError(
-				"Invalid Configuration",
-				"If `skip_renew` is set to `false`, `renew_url`, `renew_method`, and `renew_response_codes` must be provided.",
-			)
-			return
-		}
+	if renew.Url == "" || renew.Method == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Configuration",
+			"If `skip_renew` is set to `false`, `renew_url`, `renew_method`, and `renew_response_codes` must be provided.",
+		)
+		return
 	}
 
-	// Perform external call to renew "thing" data
-
-	var client *http.Client
-
-	useTls := (!privateData.RenewCertFile.IsNull() && privateData.RenewCertFile.ValueString() != "") ||
-		(!privateData.RenewKeyFile.IsNull() && privateData.RenewKeyFile.ValueString() != "") ||
-		(!privateData.RenewCaCertFile.IsNull() && privateData.RenewCaCertFile.ValueString() != "")
-
-	if useTls {
-		tflog.Debug(ctx, "using TLS client for renew call")
-		// Build TLS Config
-		tlsConfig := &TlsConfig{
-			CertFile:        privateData.RenewCertFile.ValueString(),
-			KeyFile:         privateData.RenewKeyFile.ValueString(),
-			CaCertFile:      privateData.RenewCaCertFile.ValueString(),
-			CaCertDirectory: privateData.RenewCaCertDirectory.ValueString(),
-			SkipTlsVerify:   privateData.RenewSkipTlsVerify.ValueBool(),
-		}
+	ctx, cancel := withOperationTimeout(ctx, renew.OperationTimeout)
+	defer cancel()
 
-		// Validate TLS settings
-		if tlsConfig.CertFile != "" && tlsConfig.KeyFile == "" {
-			resp.Diagnostics.AddError("Validation Error", "`renew_key_file` must be set if `renew_cert_file` is set.")
-			return
-		}
-
-		// Create TLS-enabled client
-		client, err = createTlsClient(tlsConfig)
+	if privateData.Responses != nil {
+		var err error
+		renew, err = renew.render(privateData.Responses.templateData())
 		if err != nil {
-			resp.Diagnostics.AddError("TLS Client Creation Failed", err.Error())
+			resp.Diagnostics.AddError("Renew Error", err.Error())
 			return
 		}
-
-	} else {
-		// Use default non-TLS client
-		tflog.Debug(ctx, "using default client for renew call")
-		client = &http.Client{
-			Timeout: 30 * time.Second,
-		}
 	}
 
-	reqBody := []byte(privateData.RenewRequestBody.ValueString())
-	request, err := http.NewRequest(privateData.RenewMethod.ValueString(), privateData.RenewUrl.ValueString(), bytes.NewBuffer(reqBody))
+	client, request, err := renew.newRequest()
 	if err != nil {
-		resp.Diagnostics.AddError("HTTP Request Creation Failed", err.Error())
+		resp.Diagnostics.AddError("Renew Error", err.Error())
 		return
 	}
 
-	// Add headers
-	if !privateData.RenewHeaders.IsNull() && !privateData.RenewHeaders.IsUnknown() {
-		for k, v := range privateData.RenewHeaders.Elements() {
-			if strVal, ok := v.(types.String); ok {
-				request.Header.Set(k, strVal.ValueString())
-			}
-		}
-	}
-
-	tflog.Debug(ctx, fmt.Sprintf("Parameters: %v\n", privateData.RenewRequestParameters.Elements()))
+	ctx = e.logging.withMasking(ctx, request, renew.Secrets.loggedBody())
+	e.logging.logRequest(ctx, "Renew", request, renew.Secrets.loggedBody())
 
-	// Add query parameters
-	if !privateData.RenewRequestParameters.IsNull() && !privateData.RenewRequestParameters.IsUnknown() {
-		params := request.URL.Query()
-		for k, v := range privateData.RenewRequestParameters.Elements() {
-			if strVal, ok := v.(types.String); ok {
-				params.Add(k, strVal.ValueString())
-			}
-		}
-		request.URL.RawQuery = params.Encode()
-		tflog.Debug(ctx, fmt.Sprintf("Renew URL string: %s\n", request.URL.String()))
-	}
-	privateData.RenewRequestUrlString = types.StringValue(request.URL.String())
-	tflog.Debug(ctx, fmt.Sprintf("Data Source API Call: \nURL: %s\nHeaders: %s\nMethod: %s\nRequest Body: %s\n", request.URL.String(), request.Header, request.Method, request.Body))
-
-	timeout := 10 * time.Second
-	if !privateData.RenewTimeout.IsNull() {
-		timeout = time.Duration(privateData.RenewTimeout.ValueInt64()) * time.Second
+	result, err := executeRequest(ctx, client, request, renew.options("Renew", e.logging))
+	if err != nil {
+		e.handleRenewFailure(ctx, privateData, err, resp)
+		return
 	}
+	tflog.Debug(ctx, fmt.Sprintf("Renew request completed successfully with status code %d", result.StatusCode))
 
-	var body []byte
-	var bodyString string
-	var statusCode int
-	retryCount := 0
-
-	for {
-		ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
-
-		response, err := client.Do(request.WithContext(ctxWithTimeout))
-		if err != nil {
-			if retryCount < int(privateData.RenewMaxRetry.ValueInt64()) {
-				retryCount++
-				time.Sleep(time.Duration(privateData.RenewRetryInterval.ValueInt64()) * time.Second)
-				continue
-			}
-			resp.Diagnostics.AddError("Request Failed", err.Error())
-			return
-		}
-
-		defer func(Body io.ReadCloser) {
-			err := Body.Close()
-			if err != nil {
-				return
-			}
-		}(response.Body)
-		body, _ = io.ReadAll(response.Body)
-		statusCode = response.StatusCode
-
-		bodyString = string(body)
-		if bodyString == "" {
-			bodyString = "{}"
-		}
-
-		var responseCodes []string
-		for _, v := range privateData.RenewResponseCodes.Elements() {
-			if strVal, ok := v.(types.String); ok {
-				responseCodes = append(responseCodes, strVal.ValueString())
-			}
-		}
-
-		if responseCodeChecker(responseCodes, strconv.Itoa(statusCode)) {
-			break
-		}
-
-		if retryCount < int(privateData.RenewMaxRetry.ValueInt64()) {
-			retryCount++
-			time.Sleep(time.Duration(privateData.RenewRetryInterval.ValueInt64()) * time.Second)
-		} else {
-			resp.Diagnostics.AddError("Unexpected Response Code", fmt.Sprintf("Received status code: %d", statusCode))
+	if privateData.Responses != nil {
+		// Later renew and close requests use the values of this response.
+		privateData.Responses.setLatest(request, result)
+		resp.Diagnostics.Append(privateData.save(ctx, resp.Private)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
 
-	privateData.RenewRequestUrlString = types.StringValue(request.URL.String())
-	privateData.RenewResponse = types.StringValue(bodyString)
-	privateData.StatusCode = types.StringValue(strconv.Itoa(statusCode))
-
 	// Renew again
-	resp.RenewAt = time.Now().Add(time.Duration(privateData.RenewInterval.ValueInt64()) * time.Second)
-
+	now := time.Now()
+	resp.RenewAt, diags = nextRenewAt(privateData.RenewSchedule, privateData.RenewInterval, result.Body, now)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, fmt.Sprintf("Setting RenewAt to: %s (in %d seconds)", resp.RenewAt, resp.RenewAt.Sub(now)/time.Second))
 }
 
 func (e *EphemeralCurlResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
-	privateBytes, diags := req.Private.GetKey(ctx, "response")
+	privateData, diags := getEphemeralPrivateData(ctx, req.Private)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
-		tflog.Error(ctx, "Error getting private data at close step")
-		return
-	}
-
-	//tflog.Debug(ctx, fmt.Sprintf("Raw privateBytes received: %s", string(privateBytes)))
-
-	var privateMap map[string]interface{}
-	err := json.Unmarshal(privateBytes, &privateMap)
-	if err != nil {
-		resp.Diagnostics.AddError("Error unmarshaling response", fmt.Sprintf("%s", err))
-		return
-	}
-
-	closeMethod, ok := privateMap["CloseMethod"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "CloseMethod is not a string")
-		return
-	}
-
-	closeUrl, ok := privateMap["CloseUrl"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "CloseUrl is not a string")
-		return
-	}
-
-	skipClose, ok := privateMap["SkipClose"].(bool)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "SkipClose is not a boolean")
-		return
-	}
-
-	var closeResponseCodes []string
-
-	// Check if the key exists AND is not nil before asserting type
-	if rawList, exists := privateMap["CloseResponseCodes"]; exists && rawList != nil {
-		switch v := rawList.(type) {
-		case []interface{}:
-			for _, item := range v {
-				if strVal, ok := item.(string); ok {
-					closeResponseCodes = append(closeResponseCodes, strVal)
-				} else {
-					resp.Diagnostics.AddError("Type Assertion Error", "CloseResponseCodes contains non-string elements")
-					return
-				}
-			}
-		case []string:
-			closeResponseCodes = v
-		default:
-			resp.Diagnostics.AddError(
-				"Type Assertion Error",
-				fmt.Sprintf("CloseResponseCodes has unexpected type: %T", rawList),
-			)
-			return
-		}
-	} else {
-		// If attribute is missing, default to an empty slice without throwing an error
-		closeResponseCodes = []string{}
-	}
-
-	// Convert to Terraform ListValue
-	closeResponseCodesList, diags := types.ListValue(
-		types.StringType,
-		convertStringSliceToTFValues(closeResponseCodes),
-	)
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
-		return
-	}
-
-	tflog.Debug(ctx, fmt.Sprintf("Converted response codes list: %v", privateMap["CloseResponseCodes"]))
-
-	closeRequestBody, ok := privateMap["CloseRequestBody"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "CloseRequestBody is not a string")
-		return
-	}
-
-	closeHeaders := make(map[string]string) // Default to empty map
-	if rawCloseHeaders, exists := privateMap["CloseHeaders"]; exists && rawCloseHeaders != nil {
-		if rawHeaders, ok := rawCloseHeaders.(map[string]interface{}); ok {
-			for key, value := range rawHeaders {
-				if strValue, ok := value.(string); ok {
-					closeHeaders[key] = strValue
-				} else {
-					resp.Diagnostics.AddError("Type Assertion Error", fmt.Sprintf("CloseHeaders[%s] is not a string", key))
-					return
-				}
-			}
-		} else {
-			resp.Diagnostics.AddError("Type Assertion Error", "CloseHeaders is not a map[string]interface{}")
-			return
-		}
-	}
-
-	// Convert to Terraform types.Map
-	closeHeadersTF, diags := types.MapValue(types.StringType, convertStringMapToTFValues(closeHeaders))
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
-		return
-	}
-
-	closeParameters := make(map[string]string) // Default to empty map
-
-	if rawCloseParameters, exists := privateMap["CloseRequestParameters"]; exists && rawCloseParameters != nil {
-		if rawParameters, ok := rawCloseParameters.(map[string]interface{}); ok {
-			for key, value := range rawParameters {
-				if strValue, ok := value.(string); ok {
-					closeParameters[key] = strValue
-				} else {
-					resp.Diagnostics.AddError("Type Assertion Error", fmt.Sprintf("CloseRequestParameters[%s] is not a string", key))
-					return
-				}
-			}
-		} else {
-			resp.Diagnostics.AddError("Type Assertion Error", "CloseRequestParameters is not a map[string]interface{}")
-			return
-		}
-	}
-
-	// Convert to Terraform types.Map
-	closeParametersTF, diags := types.MapValue(types.StringType, convertStringMapToTFValues(closeParameters))
-	if diags.HasError() {
-		resp.Diagnostics.Append(diags...)
-		return
-	}
-
-	closeRequestUrlString, ok := privateMap["CloseRequestUrlString"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseRequestUrlString is not a string")
-		return
-	}
-
-	closeCertFile, ok := privateMap["CloseCertFile"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseCertFile is not a string")
-		return
-	}
-
-	closeKeyFile, ok := privateMap["CloseKeyFile"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseKeyFile is not a string")
-		return
-	}
-
-	closeCaCertFile, ok := privateMap["CloseCaCertFile"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseCaCertFile is not a string")
-		return
-	}
-
-	closeCaCertDirectory, ok := privateMap["CloseCaCertDirectory"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseCaCertDirectory is not a string")
-		return
-	}
-
-	closeSkipTlsVerify, ok := privateMap["CloseSkipTlsVerify"].(bool)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseSkipTlsVerify is not a bool")
-		return
-	}
-
-	closeRetryIntervalFloat, ok := privateMap["CloseRetryInterval"].(float64)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "CloseRetryInterval is not a float64 (expected int64)")
-		return
-	}
-	closeRetryInterval := int64(closeRetryIntervalFloat)
-
-	closeMaxRetryFloat, ok := privateMap["CloseMaxRetry"].(float64)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "CloseMaxRetry is not a float64 (expected int64)")
-		return
-	}
-	closeMaxRetry := int64(closeMaxRetryFloat)
-
-	closeTimeoutFloat, ok := privateMap["CloseTimeout"].(float64)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", "CloseTimeout is not a float64 (expected int64)")
-		return
-	}
-	closeTimeout := int64(closeTimeoutFloat)
-
-	closeResponse, ok := privateMap["CloseResponse"].(string)
-	if !ok {
-		resp.Diagnostics.AddError("Type Assertion Error", " CloseResponse is not a string")
 		return
 	}
 
-	privateData := CurlEphemeralModel{
-		CloseMethod:            types.StringValue(closeMethod),
-		CloseUrl:               types.StringValue(closeUrl),
-		SkipClose:              types.BoolValue(skipClose),
-		CloseResponseCodes:     closeResponseCodesList,
-		CloseHeaders:           closeHeadersTF,
-		CloseRequestParameters: closeParametersTF,
-		CloseRequestBody:       types.StringValue(closeRequestBody),
-		CloseRequestUrlString:  types.StringValue(closeRequestUrlString),
-		CloseCertFile:          types.StringValue(closeCertFile),
-		CloseKeyFile:           types.StringValue(closeKeyFile),
-		CloseCaCertFile:        types.StringValue(closeCaCertFile),
-		CloseCaCertDirectory:   types.StringValue(closeCaCertDirectory),
-		CloseSkipTlsVerify:     types.BoolValue(closeSkipTlsVerify),
-		CloseRetryInterval:     types.Int64Value(closeRetryInterval),
-		CloseMaxRetry:          types.Int64Value(closeMaxRetry),
-		CloseTimeout:           types.Int64Value(closeTimeout),
-		CloseResponse:          types.StringValue(closeResponse),
-	}
-
-	// Perform external call to close/clean up data
-
-	if privateData.SkipClose.IsUnknown() {
-		tflog.Debug(ctx, "`skip_close` not set. using default value `true`")
-		privateData.SkipClose = types.BoolValue(true)
-	}
-
-	if privateData.SkipClose.ValueBool() {
+	closeRequest := privateData.Close
+	if closeRequest == nil {
 		tflog.Debug(ctx, "`skip_close` set to `true`. Skipping close call.")
 		return
 	}
 
-	var client *http.Client
-	useCloseTls := hasValue(privateData.CloseCertFile) ||
-		hasValue(privateData.CloseKeyFile) ||
-		hasValue(privateData.CloseCaCertFile)
-
-	if useCloseTls {
-		tflog.Debug(ctx, "Using custom TLS client for Close() operation")
-
-		closeTlsConfig := &TlsConfig{
-			CertFile:        privateData.CloseCertFile.ValueString(),
-			KeyFile:         privateData.CloseKeyFile.ValueString(),
-			CaCertFile:      privateData.CloseCaCertFile.ValueString(),
-			CaCertDirectory: privateData.CloseCaCertDirectory.ValueString(),
-			SkipTlsVerify:   privateData.CloseSkipTlsVerify.ValueBool(),
-		}
+	ctx, cancel := withOperationTimeout(ctx, closeRequest.OperationTimeout)
+	defer cancel()
 
-		tlsClient, err := createTlsClient(closeTlsConfig)
+	if privateData.Responses != nil {
+		var err error
+		closeRequest, err = closeRequest.render(privateData.Responses.templateData())
 		if err != nil {
-			resp.Diagnostics.AddError("Close Error", fmt.Sprintf("Failed to create TLS client: %s", err))
+			resp.Diagnostics.AddError("Close Error", err.Error())
 			return
 		}
-		client = tlsClient
-	} else {
-		// Default non-TLS client
-		tflog.Debug(ctx, "Using default HTTP client for Close() operation")
-		client = &http.Client{Timeout: 30 * time.Second}
-	}
-
-	// Build Close Request
-	var reqBody io.Reader = nil
-	if !privateData.CloseRequestBody.IsNull() && !privateData.CloseRequestBody.IsUnknown() {
-		reqBody = bytes.NewBuffer([]byte(privateData.CloseRequestBody.ValueString()))
 	}
-	tflog.Debug(ctx, fmt.Sprintf("Method: %s \n Url: %s \n", privateData.CloseMethod.ValueString(), privateData.CloseUrl.ValueString()))
 
-	request, err := http.NewRequest(privateData.CloseMethod.ValueString(), privateData.CloseUrl.ValueString(), reqBody)
+	client, request, err := closeRequest.newRequest()
 	if err != nil {
-		resp.Diagnostics.AddError("Close Error", fmt.Sprintf("Failed to create request: %s", err))
+		resp.Diagnostics.AddError("Close Error", err.Error())
 		return
 	}
 
-	if !privateData.CloseHeaders.IsNull() && !privateData.CloseHeaders.IsUnknown() {
-		for k, v := range privateData.CloseHeaders.Elements() {
-			if strVal, ok := v.(types.String); ok {
-				request.Header.Set(k, strVal.ValueString())
-			}
-		}
-	} else {
-		tflog.Debug(ctx, "No CloseHeaders provided, proceeding without headers")
-	}
-
-	// Add Query Parameters
-	if !privateData.CloseRequestParameters.IsNull() && !privateData.CloseRequestParameters.IsUnknown() {
-		params := request.URL.Query()
-		for k, v := range privateData.CloseRequestParameters.Elements() {
-			if strVal, ok := v.(types.String); ok {
-				params.Add(k, strVal.ValueString())
-			}
-		}
-		request.URL.RawQuery = params.Encode()
-	}
-
-	// Execute Request with Retry Logic
-	timeout := time.Duration(privateData.CloseTimeout.ValueInt64()) * time.Second
-	retryInterval := time.Duration(privateData.CloseRetryInterval.ValueInt64()) * time.Second
-	maxRetry := int(privateData.CloseMaxRetry.ValueInt64())
-
-	tflog.Debug(ctx, "Ephemeral resource close API request", map[string]interface{}{
-		"url":     request.URL.String(),
-		"method":  request.Method,
-		"headers": request.Header,
-	})
-
-	var bodyBytes []byte
-	var statusCode int
-	var retryCount int
-
-	for {
-		ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
-
-		response, err := client.Do(request.WithContext(ctxWithTimeout))
-		if err != nil {
-			if retryCount < maxRetry {
-				retryCount++
-				tflog.Warn(ctx, fmt.Sprintf("Close request failed, retrying... Attempt %d/%d", retryCount, maxRetry))
-				time.Sleep(retryInterval)
-				continue
-			}
-			resp.Diagnostics.AddError("Close Error", fmt.Sprintf("Request failed: %s", err))
-			return
-		}
+	ctx = e.logging.withMasking(ctx, request, closeRequest.Secrets.loggedBody())
+	e.logging.logRequest(ctx, "Close", request, closeRequest.Secrets.loggedBody())
 
-		bodyBytes, _ = io.ReadAll(response.Body)
-		statusCode = response.StatusCode
-		err = response.Body.Close()
-		if err != nil {
-			return
-		}
-
-		var expectedCodes []string
-		tflog.Debug(ctx, fmt.Sprintf("private data response code list: %v", privateData.CloseResponseCodes.Elements()))
-		for _, v := range privateData.CloseResponseCodes.Elements() {
-			if strVal, ok := v.(types.String); ok {
-				expectedCodes = append(expectedCodes, strVal.ValueString())
-			}
-		}
-
-		tflog.Debug(ctx, fmt.Sprintf("response code received: %v", statusCode))
-		tflog.Debug(ctx, fmt.Sprintf("expected response code received: %v", expectedCodes))
-
-		// Validate Response Code
-		if responseCodeChecker(expectedCodes, strconv.Itoa(statusCode)) {
-			tflog.Debug(ctx, "Close request completed successfully")
-			break
-		} else {
-			if retryCount < maxRetry {
-				retryCount++
-				tflog.Warn(ctx, fmt.Sprintf(
-					"Close request returned unexpected status %d. Retrying (%d/%d)",
-					statusCode, retryCount, maxRetry,
-				))
-				time.Sleep(retryInterval)
-				continue
-			}
+	result, err := executeRequest(ctx, client, request, closeRequest.options("Close", e.logging))
+	if err != nil {
+		var statusErr *unexpectedStatusError
+		var assertErr *assertionError
+		var interruptErr *interruptedError
+		switch {
+		case errors.As(err, &interruptErr):
+			resp.Diagnostics.AddError("Close Interrupted", interruptErr.Error())
+		case errors.As(err, &assertErr):
+			resp.Diagnostics.AddError("Close Error", assertErr.Error())
+		case errors.As(err, &statusErr):
 			resp.Diagnostics.AddError(
 				"Close Error",
-				fmt.Sprintf("Unexpected response code from close request: %d. Response: %s", statusCode, string(bodyBytes)),
+				fmt.Sprintf("Unexpected response code from close request: %d. Response: %s", statusErr.Result.StatusCode, string(statusErr.Result.Body)),
 			)
-			return
+		default:
+			resp.Diagnostics.AddError("Close Error", fmt.Sprintf("Request failed: %s", err))
 		}
+		return
 	}
 
+	tflog.Debug(ctx, fmt.Sprintf("Close request completed successfully with status code %d", result.StatusCode))
 }
 
 func (e EphemeralCurlResource) ConfigValidators(ctx context.Context) []ephemeral.ConfigValidator {
-	return []ephemeral.ConfigValidator{
+	validators := []ephemeral.ConfigValidator{
 		ephemeralvalidator.RequiredTogether(
 			path.MatchRoot("cert_file"),
 			path.MatchRoot("key_file"),
@@ -1580,5 +895,17 @@ func (e EphemeralCurlResource) ConfigValidators(ctx context.Context) []ephemeral
 			path.MatchRoot("close_retry_interval"),
 			path.MatchRoot("close_max_retry"),
 		),
+		ephemeralvalidator.Conflicting(
+			path.MatchRoot("request_body"),
+			path.MatchRoot("multipart"),
+		),
+	}
+
+	conflicts := formBodyConflicts("", "request_body", "multipart")
+	conflicts = append(conflicts, formBodyConflicts("renew_", "request_body")...)
+	conflicts = append(conflicts, formBodyConflicts("close_", "request_body")...)
+	for _, pair := range conflicts {
+		validators = append(validators, ephemeralvalidator.Conflicting(pair...))
 	}
+	return validators
 }
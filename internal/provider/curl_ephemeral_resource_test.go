@@ -850,3 +850,90 @@ func TestAccCurlEmphemeralResourceWithTLSSkipVerify(t *testing.T) {
 		},
 	})
 }
+
+const testAccEphemeralResourceTemplates = `
+ephemeral "terracurl_request" "ephems" {
+  method         = "POST"
+  name           = "test"
+  response_codes = ["201"]
+  url            = "https://example.com/sessions"
+
+  skip_renew           = false
+  renew_interval       = "-10"
+  renew_url            = "https://example.com/sessions/{{ .Body.id }}/renew"
+  renew_response_codes = ["200"]
+  renew_method         = "POST"
+  renew_headers = {
+    Authorization = "Bearer {{ .Body.token }}"
+  }
+
+  skip_close           = false
+  close_url            = "https://example.com/sessions/{{ .Open.Body.id }}"
+  close_response_codes = ["204"]
+  close_method         = "DELETE"
+  close_headers = {
+    Authorization = "Bearer {{ .Body.token }}"
+  }
+}
+
+provider "echo" {
+  data = ephemeral.terracurl_request.ephems
+}
+
+resource "echo" "test" {}
+`
+
+func TestAccEphemeralResourceTemplates(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+	skipIfTerraformIsLegacy(t)
+
+	var renewTokens, closeTokens []string
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/sessions",
+		httpmock.NewStringResponder(201, `{"id": "abc", "token": "open-token"}`),
+	)
+	httpmock.RegisterResponder("POST", "https://example.com/sessions/abc/renew",
+		func(req *http.Request) (*http.Response, error) {
+			renewTokens = append(renewTokens, req.Header.Get("Authorization"))
+			return httpmock.NewStringResponse(200, `{"id": "abc", "token": "renewed-token"}`), nil
+		},
+	)
+	httpmock.RegisterResponder("DELETE", "https://example.com/sessions/abc",
+		func(req *http.Request) (*http.Response, error) {
+			closeTokens = append(closeTokens, req.Header.Get("Authorization"))
+			return httpmock.NewStringResponse(204, ""), nil
+		},
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEphemeralResourceTemplates,
+				Check: resource.ComposeTestCheckFunc(
+					testMockEndpointRegister("POST https://example.com/sessions"),
+					testMockEndpointRegister("DELETE https://example.com/sessions/abc"),
+				),
+			},
+		},
+	})
+
+	if len(renewTokens) > 0 && renewTokens[0] != "Bearer open-token" {
+		t.Errorf("expected the first renew request to use the open response, got %q", renewTokens[0])
+	}
+	for _, token := range closeTokens {
+		expected := "Bearer open-token"
+		if len(renewTokens) > 0 {
+			expected = "Bearer renewed-token"
+		}
+		if token != expected {
+			t.Errorf("expected the close request to use %q, got %q", expected, token)
+		}
+	}
+}
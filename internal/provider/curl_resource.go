@@ -4,14 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"io"
 	"net/http"
@@ -23,6 +24,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -34,6 +36,7 @@ const (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CurlResource{}
 var _ resource.ResourceWithImportState = &CurlResource{}
+var _ resource.ResourceWithIdentity = &CurlResource{}
 
 func NewCurlResource() resource.Resource {
 	return &CurlResource{}
@@ -41,64 +44,122 @@ func NewCurlResource() resource.Resource {
 
 // CurlResource defines the resource implementation.
 type CurlResource struct {
-	client *http.Client
+	client      *http.Client
+	retryPolicy *retryPolicy
+	logging     *loggingOptions
 }
 
 // CurlResourceModel describes the resource data model.
 type CurlResourceModel struct {
-	Id                       types.String `tfsdk:"id"`
-	Name                     types.String `tfsdk:"name"`
-	Url                      types.String `tfsdk:"url"`
-	Method                   types.String `tfsdk:"method"`
-	RequestBody              types.String `tfsdk:"request_body"`
-	Headers                  types.Map    `tfsdk:"headers"`
-	RequestParameters        types.Map    `tfsdk:"request_parameters"`
-	RequestUrlString         types.String `tfsdk:"request_url_string"`
-	CertFile                 types.String `tfsdk:"cert_file"`
-	KeyFile                  types.String `tfsdk:"key_file"`
-	CaCertFile               types.String `tfsdk:"ca_cert_file"`
-	CaCertDirectory          types.String `tfsdk:"ca_cert_directory"`
-	SkipTlsVerify            types.Bool   `tfsdk:"skip_tls_verify"`
-	RetryInterval            types.Int64  `tfsdk:"retry_interval"`
-	MaxRetry                 types.Int64  `tfsdk:"max_retry"`
-	Timeout                  types.Int64  `tfsdk:"timeout"`
-	Response                 types.String `tfsdk:"response"`
-	ResponseCodes            types.List   `tfsdk:"response_codes"`
-	StatusCode               types.String `tfsdk:"status_code"`
-	SkipDestroy              types.Bool   `tfsdk:"skip_destroy"`
-	DestroyUrl               types.String `tfsdk:"destroy_url"`
-	DestroyMethod            types.String `tfsdk:"destroy_method"`
-	DestroyRequestBody       types.String `tfsdk:"destroy_request_body"`
-	DestroyHeaders           types.Map    `tfsdk:"destroy_headers"`
-	DestroyRequestParameters types.Map    `tfsdk:"destroy_request_parameters"`
-	DestroyRequestUrlString  types.String `tfsdk:"destroy_request_url_string"`
-	DestroyCertFile          types.String `tfsdk:"destroy_cert_file"`
-	DestroyKeyFile           types.String `tfsdk:"destroy_key_file"`
-	DestroyCaCertFile        types.String `tfsdk:"destroy_ca_cert_file"`
-	DestroyCaCertDirectory   types.String `tfsdk:"destroy_ca_cert_directory"`
-	DestroySkipTlsVerify     types.Bool   `tfsdk:"destroy_skip_tls_verify"`
-	DestroyRetryInterval     types.Int64  `tfsdk:"destroy_retry_interval"`
-	DestroyMaxRetry          types.Int64  `tfsdk:"destroy_max_retry"`
-	DestroyTimeout           types.Int64  `tfsdk:"destroy_timeout"`
-	DestroyResponseCodes     types.List   `tfsdk:"destroy_response_codes"`
-	SkipRead                 types.Bool   `tfsdk:"skip_read"`
-	ReadUrl                  types.String `tfsdk:"read_url"`
-	ReadMethod               types.String `tfsdk:"read_method"`
-	ReadHeaders              types.Map    `tfsdk:"read_headers"`
-	ReadParameters           types.Map    `tfsdk:"read_parameters"`
-	ReadRequestBody          types.String `tfsdk:"read_request_body"`
-	ReadCertFile             types.String `tfsdk:"read_cert_file"`
-	ReadKeyFile              types.String `tfsdk:"read_key_file"`
-	ReadCaCertFile           types.String `tfsdk:"read_ca_cert_file"`
-	ReadCaCertDirectory      types.String `tfsdk:"read_ca_cert_directory"`
-	ReadSkipTlsVerify        types.Bool   `tfsdk:"read_skip_tls_verify"`
-	ReadResponseCodes        types.List   `tfsdk:"read_response_codes"`
-	DriftMarker              types.String `tfsdk:"drift_marker"`
-	IgnoreResponseFields     types.List   `tfsdk:"ignore_response_fields"`
+	Id                           types.String   `tfsdk:"id"`
+	Name                         types.String   `tfsdk:"name"`
+	Url                          types.String   `tfsdk:"url"`
+	Method                       types.String   `tfsdk:"method"`
+	RequestBody                  types.String   `tfsdk:"request_body"`
+	Headers                      types.Map      `tfsdk:"headers"`
+	RequestParameters            types.Map      `tfsdk:"request_parameters"`
+	RequestUrlString             types.String   `tfsdk:"request_url_string"`
+	CertFile                     types.String   `tfsdk:"cert_file"`
+	KeyFile                      types.String   `tfsdk:"key_file"`
+	CaCertFile                   types.String   `tfsdk:"ca_cert_file"`
+	CaCertDirectory              types.String   `tfsdk:"ca_cert_directory"`
+	SkipTlsVerify                types.Bool     `tfsdk:"skip_tls_verify"`
+	RetryInterval                types.Int64    `tfsdk:"retry_interval"`
+	MaxRetry                     types.Int64    `tfsdk:"max_retry"`
+	Timeout                      types.Int64    `tfsdk:"timeout"`
+	Response                     types.String   `tfsdk:"response"`
+	ResponseCodes                types.List     `tfsdk:"response_codes"`
+	StatusCode                   types.String   `tfsdk:"status_code"`
+	SkipDestroy                  types.Bool     `tfsdk:"skip_destroy"`
+	DestroyUrl                   types.String   `tfsdk:"destroy_url"`
+	DestroyMethod                types.String   `tfsdk:"destroy_method"`
+	DestroyRequestBody           types.String   `tfsdk:"destroy_request_body"`
+	DestroyHeaders               types.Map      `tfsdk:"destroy_headers"`
+	DestroyRequestParameters     types.Map      `tfsdk:"destroy_request_parameters"`
+	DestroyRequestUrlString      types.String   `tfsdk:"destroy_request_url_string"`
+	DestroyCertFile              types.String   `tfsdk:"destroy_cert_file"`
+	DestroyKeyFile               types.String   `tfsdk:"destroy_key_file"`
+	DestroyCaCertFile            types.String   `tfsdk:"destroy_ca_cert_file"`
+	DestroyCaCertDirectory       types.String   `tfsdk:"destroy_ca_cert_directory"`
+	DestroySkipTlsVerify         types.Bool     `tfsdk:"destroy_skip_tls_verify"`
+	DestroyRetryInterval         types.Int64    `tfsdk:"destroy_retry_interval"`
+	DestroyMaxRetry              types.Int64    `tfsdk:"destroy_max_retry"`
+	DestroyTimeout               types.Int64    `tfsdk:"destroy_timeout"`
+	DestroyResponseCodes         types.List     `tfsdk:"destroy_response_codes"`
+	SkipRead                     types.Bool     `tfsdk:"skip_read"`
+	ReadUrl                      types.String   `tfsdk:"read_url"`
+	ReadMethod                   types.String   `tfsdk:"read_method"`
+	ReadHeaders                  types.Map      `tfsdk:"read_headers"`
+	ReadParameters               types.Map      `tfsdk:"read_parameters"`
+	ReadRequestBody              types.String   `tfsdk:"read_request_body"`
+	ReadCertFile                 types.String   `tfsdk:"read_cert_file"`
+	ReadKeyFile                  types.String   `tfsdk:"read_key_file"`
+	ReadCaCertFile               types.String   `tfsdk:"read_ca_cert_file"`
+	ReadCaCertDirectory          types.String   `tfsdk:"read_ca_cert_directory"`
+	ReadSkipTlsVerify            types.Bool     `tfsdk:"read_skip_tls_verify"`
+	ReadResponseCodes            types.List     `tfsdk:"read_response_codes"`
+	ReadRetryInterval            types.Int64    `tfsdk:"read_retry_interval"`
+	ReadMaxRetry                 types.Int64    `tfsdk:"read_max_retry"`
+	ReadTimeout                  types.Int64    `tfsdk:"read_timeout"`
+	ReadRetryPolicy              types.Object   `tfsdk:"read_retry_policy"`
+	DriftMarker                  types.String   `tfsdk:"drift_marker"`
+	IgnoreResponseFields         types.List     `tfsdk:"ignore_response_fields"`
+	Assert                       types.List     `tfsdk:"assert"`
+	ReadAssert                   types.List     `tfsdk:"read_assert"`
+	DestroyAssert                types.List     `tfsdk:"destroy_assert"`
+	UpdateAssert                 types.List     `tfsdk:"update_assert"`
+	UpdateUrl                    types.String   `tfsdk:"update_url"`
+	UpdateMethod                 types.String   `tfsdk:"update_method"`
+	UpdateRequestBody            types.String   `tfsdk:"update_request_body"`
+	UpdateHeaders                types.Map      `tfsdk:"update_headers"`
+	UpdateRequestParameters      types.Map      `tfsdk:"update_request_parameters"`
+	UpdateResponseCodes          types.List     `tfsdk:"update_response_codes"`
+	WaitFor                      types.Object   `tfsdk:"wait_for"`
+	WaitForResponse              types.String   `tfsdk:"wait_for_response"`
+	RetryPolicy                  types.Object   `tfsdk:"retry_policy"`
+	DestroyRetryPolicy           types.Object   `tfsdk:"destroy_retry_policy"`
+	IdempotencyKey               types.Bool     `tfsdk:"idempotency_key"`
+	IdempotencyKeyHeader         types.String   `tfsdk:"idempotency_key_header"`
+	OptimisticLocking            types.Bool     `tfsdk:"optimistic_locking"`
+	SkipConditionalRead          types.Bool     `tfsdk:"skip_conditional_read"`
+	AdoptExisting                types.Bool     `tfsdk:"adopt_existing"`
+	AdoptOnResponseCodes         types.List     `tfsdk:"adopt_on_response_codes"`
+	OnCreateFailure              types.String   `tfsdk:"on_create_failure"`
+	HeadersWo                    types.Map      `tfsdk:"headers_wo"`
+	HeadersWoVersion             types.Int64    `tfsdk:"headers_wo_version"`
+	RequestBodyWo                types.String   `tfsdk:"request_body_wo"`
+	RequestBodyWoVersion         types.Int64    `tfsdk:"request_body_wo_version"`
+	UpdateHeadersWo              types.Map      `tfsdk:"update_headers_wo"`
+	UpdateRequestBodyWo          types.String   `tfsdk:"update_request_body_wo"`
+	UpdateWoVersion              types.Int64    `tfsdk:"update_wo_version"`
+	Multipart                    types.Object   `tfsdk:"multipart"`
+	UpdateMultipart              types.Object   `tfsdk:"update_multipart"`
+	FormBody                     types.Map      `tfsdk:"form_body"`
+	FormBodyValues               types.Map      `tfsdk:"form_body_values"`
+	ReadFormBody                 types.Map      `tfsdk:"read_form_body"`
+	ReadFormBodyValues           types.Map      `tfsdk:"read_form_body_values"`
+	UpdateFormBody               types.Map      `tfsdk:"update_form_body"`
+	UpdateFormBodyValues         types.Map      `tfsdk:"update_form_body_values"`
+	DestroyFormBody              types.Map      `tfsdk:"destroy_form_body"`
+	DestroyFormBodyValues        types.Map      `tfsdk:"destroy_form_body_values"`
+	RequestBodyFile              types.String   `tfsdk:"request_body_file"`
+	RequestBodyFileSha256        types.String   `tfsdk:"request_body_file_sha256"`
+	ReadRequestBodyFile          types.String   `tfsdk:"read_request_body_file"`
+	ReadRequestBodyFileSha256    types.String   `tfsdk:"read_request_body_file_sha256"`
+	UpdateRequestBodyFile        types.String   `tfsdk:"update_request_body_file"`
+	UpdateRequestBodyFileSha256  types.String   `tfsdk:"update_request_body_file_sha256"`
+	DestroyRequestBodyFile       types.String   `tfsdk:"destroy_request_body_file"`
+	DestroyRequestBodyFileSha256 types.String   `tfsdk:"destroy_request_body_file_sha256"`
+	RequestBodyFileChunked       types.Bool     `tfsdk:"request_body_file_chunked"`
+	SensitiveResponseFields      types.List     `tfsdk:"sensitive_response_fields"`
+	SensitiveResponse            types.Map      `tfsdk:"sensitive_response"`
+	Timeouts                     timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *CurlResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_request"
+	// read_url, part of the identity, can be updated in place.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -113,7 +174,7 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Example identifier",
+				MarkdownDescription: "Identifier of the resource, set to `name`. To import an existing object, use an import ID that sets the arguments of the resource, either as a JSON object or as comma separated `key=value` pairs. Repeat a key to add list elements and use `key.name=value` for map elements. The import ID must include `name`, `url`, `method`, `response_codes`, `read_url`, `read_method` and `read_response_codes`. The read request is sent to populate `response` and `status_code`. Terraform 1.12 and later can also import by resource identity, made of `id` and `read_url`. The object is then read with a GET request and the other arguments are taken from the configuration on the next apply",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -122,21 +183,21 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Required:            true,
 				MarkdownDescription: "Api endpoint to call",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"method": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "HTTP method to use in the API call",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"request_body": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "A request body to attach to the API call",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"headers": schema.MapAttribute{
@@ -144,7 +205,7 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				MarkdownDescription: "Map of headers to attach to the API call",
 				PlanModifiers: []planmodifier.Map{
-					mapplanmodifier.RequiresReplace(),
+					mapRequiresReplace(),
 				},
 			},
 			"request_parameters": schema.MapAttribute{
@@ -152,7 +213,72 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				MarkdownDescription: "Map of parameters to attach to the API call",
 				PlanModifiers: []planmodifier.Map{
-					mapplanmodifier.RequiresReplace(),
+					mapRequiresReplace(),
+				},
+			},
+			"form_body": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyDescription, "create", "`request_body`, `request_body_wo`, `multipart` and `request_body_file`"),
+				PlanModifiers: []planmodifier.Map{
+					mapRequiresReplace(),
+				},
+			},
+			"form_body_values": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyValuesDescription, "create", "form_body"),
+				PlanModifiers: []planmodifier.Map{
+					mapRequiresReplace(),
+				},
+			},
+			"request_body_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(requestBodyFileDescription, "create", "request_body_file_sha256", "`request_body`, `request_body_wo`, `multipart`, `form_body` and `form_body_values`"),
+				PlanModifiers: []planmodifier.String{
+					stringRequiresReplace(),
+				},
+			},
+			"request_body_file_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf(requestBodyFileSHA256Description, "request_body_file", "the replacement of the resource"),
+				PlanModifiers: []planmodifier.String{
+					requestBodyFileHash(path.Root("request_body_file")),
+					stringRequiresReplace(),
+				},
+			},
+			"request_body_file_chunked": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Set this to true to send the `*request_body_file` bodies with chunked transfer encoding instead of a `Content-Length` header. Defaults to false",
+			},
+			"request_body_wo": schema.StringAttribute{
+				Optional:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+				MarkdownDescription: "Write-only request body to attach to the API call instead of `request_body`. It is never stored in state; change `request_body_wo_version` to send a new value. Requires Terraform 1.11 or later",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("request_body")),
+				},
+			},
+			"request_body_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of `request_body_wo`. Changing it replaces the resource, so that the create call is sent with the new value",
+				PlanModifiers: []planmodifier.Int64{
+					int64RequiresReplace(),
+				},
+			},
+			"headers_wo": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+				MarkdownDescription: "Write-only map of headers to attach to the API call, e.g. for tokens. They are never stored in state and take precedence over `headers`. Change `headers_wo_version` to send new values. Requires Terraform 1.11 or later",
+			},
+			"headers_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of `headers_wo`. Changing it replaces the resource, so that the create call is sent with the new values",
+				PlanModifiers: []planmodifier.Int64{
+					int64RequiresReplace(),
 				},
 			},
 			"request_url_string": schema.StringAttribute{
@@ -163,35 +289,35 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				MarkdownDescription: "Path to a file on local disk that contains the PEM-encoded certificate to present to the server",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"key_file": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Path to a file on local disk that contains the PEM-encoded private key for which the authentication certificate was issued",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"ca_cert_file": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Path to a file on local disk that will be used to validate the certificate presented by the server",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"ca_cert_directory": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Path to a directory on local disk that contains one or more certificate files that will be used to validate the certificate presented by the server",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"skip_tls_verify": schema.BoolAttribute{
 				Optional:            true,
 				MarkdownDescription: "Set this to true to disable verification of the server's TLS certificate",
 				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplace(),
+					boolRequiresReplace(),
 				},
 			},
 			"retry_interval": schema.Int64Attribute{
@@ -214,10 +340,22 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 				MarkdownDescription: "JSON response received from request",
 			},
+			"sensitive_response_fields": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: sensitiveResponseFieldsDescription + ". `wait_for_response` is redacted the same way",
+			},
+			"sensitive_response": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Values of the `sensitive_response_fields` found in the last response, keyed by JSON path",
+			},
 			"response_codes": schema.ListAttribute{
 				Required:            true,
-				MarkdownDescription: "A list of expected response codes",
+				MarkdownDescription: "A list of expected response codes. Supports exact codes (`200`), classes (`2xx`), inclusive ranges (`200-299`) and negations (`!409`).",
 				ElementType:         types.StringType,
+				Validators:          validResponseCodes(),
 			},
 			"status_code": schema.StringAttribute{
 				Computed:            true,
@@ -235,21 +373,52 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				MarkdownDescription: "Destroy API endpoint to call",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"destroy_method": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Destroy HTTP method to use in the API call",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"destroy_request_body": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "A request body to attach to the destroy API call",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
+				},
+			},
+			"destroy_form_body": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyDescription, "destroy", "`destroy_request_body` and `destroy_request_body_file`"),
+				PlanModifiers: []planmodifier.Map{
+					mapRequiresReplace(),
+				},
+			},
+			"destroy_form_body_values": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyValuesDescription, "destroy", "destroy_form_body"),
+				PlanModifiers: []planmodifier.Map{
+					mapRequiresReplace(),
+				},
+			},
+			"destroy_request_body_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(requestBodyFileDescription, "destroy", "destroy_request_body_file_sha256", "`destroy_request_body`, `destroy_form_body` and `destroy_form_body_values`"),
+				PlanModifiers: []planmodifier.String{
+					stringRequiresReplace(),
+				},
+			},
+			"destroy_request_body_file_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf(requestBodyFileSHA256Description, "destroy_request_body_file", "the replacement of the resource"),
+				PlanModifiers: []planmodifier.String{
+					requestBodyFileHash(path.Root("destroy_request_body_file")),
+					stringRequiresReplace(),
 				},
 			},
 			"destroy_headers": schema.MapAttribute{
@@ -257,7 +426,7 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				MarkdownDescription: "Map of headers to attach to the destroy API call",
 				PlanModifiers: []planmodifier.Map{
-					mapplanmodifier.RequiresReplace(),
+					mapRequiresReplace(),
 				},
 			},
 			"destroy_request_parameters": schema.MapAttribute{
@@ -265,7 +434,7 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				MarkdownDescription: "Map of parameters to attach to the destroy API call",
 				PlanModifiers: []planmodifier.Map{
-					mapplanmodifier.RequiresReplace(),
+					mapRequiresReplace(),
 				},
 			},
 			"destroy_request_url_string": schema.StringAttribute{
@@ -276,35 +445,35 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				MarkdownDescription: "Path to a file on local disk that contains the PEM-encoded certificate to present to the server for the destroy call",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"destroy_key_file": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Path to a file on local disk that contains the PEM-encoded private key for which the authentication certificate was issued for the destroy call",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"destroy_ca_cert_file": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Path to a file on local disk that will be used to validate the certificate presented by the server for the destroy call",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"destroy_ca_cert_directory": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Path to a directory on local disk that contains one or more certificate files that will be used to validate the certificate presented by the server for the destroy call",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringRequiresReplace(),
 				},
 			},
 			"destroy_skip_tls_verify": schema.BoolAttribute{
 				Optional:            true,
 				MarkdownDescription: "Set this to true to disable verification of the server's TLS certificate for the destroy call",
 				PlanModifiers: []planmodifier.Bool{
-					boolplanmodifier.RequiresReplace(),
+					boolRequiresReplace(),
 				},
 			},
 			"destroy_retry_interval": schema.Int64Attribute{
@@ -325,8 +494,9 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			},
 			"destroy_response_codes": schema.ListAttribute{
 				Optional:            true,
-				MarkdownDescription: "A list of expected response codes for the destroy call",
+				MarkdownDescription: "A list of expected response codes for the destroy call. Supports exact codes (`200`), classes (`2xx`), inclusive ranges (`200-299`) and negations (`!409`).",
 				ElementType:         types.StringType,
+				Validators:          validResponseCodes(),
 			},
 			"skip_read": schema.BoolAttribute{
 				Optional:            true,
@@ -355,6 +525,27 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "Optional request body to use for the read request.",
 			},
 
+			"read_form_body": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyDescription, "read", "`read_request_body` and `read_request_body_file`"),
+			},
+			"read_form_body_values": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyValuesDescription, "read", "read_form_body"),
+			},
+			"read_request_body_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(requestBodyFileDescription, "read", "read_request_body_file_sha256", "`read_request_body`, `read_form_body` and `read_form_body_values`"),
+			},
+			"read_request_body_file_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf(requestBodyFileSHA256Description, "read_request_body_file", "an update"),
+				PlanModifiers: []planmodifier.String{
+					requestBodyFileHash(path.Root("read_request_body_file")),
+				},
+			},
 			"read_parameters": schema.MapAttribute{
 				Optional:            true,
 				ElementType:         types.StringType,
@@ -387,8 +578,21 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			},
 			"read_response_codes": schema.ListAttribute{
 				Optional:            true,
-				MarkdownDescription: "Expected response codes for the read request. Required if `skip_read` is false.",
+				MarkdownDescription: "Expected response codes for the read request. Required if `skip_read` is false. Supports exact codes (`200`), classes (`2xx`), inclusive ranges (`200-299`) and negations (`!409`).",
 				ElementType:         types.StringType,
+				Validators:          validResponseCodes(),
+			},
+			"read_retry_interval": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Interval between each attempt for the read request. Defaults to 10",
+			},
+			"read_max_retry": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of retries for the read request. Defaults to 0",
+			},
+			"read_timeout": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Time in seconds before each read request times out. Defaults to 10",
 			},
 			"drift_marker": schema.StringAttribute{
 				Computed:            true,
@@ -402,6 +606,134 @@ func (r *CurlResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "List of JSON fields to ignore during drift detection.",
 				ElementType:         types.StringType,
 			},
+			"update_url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "API endpoint to call when the resource is updated in place. If not set, updates only change state. The update call uses the same TLS, retry and timeout settings as the create call.",
+			},
+			"update_method": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "HTTP method to use in the update API call",
+			},
+			"update_request_body": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A request body to attach to the update API call",
+			},
+			"update_request_body_wo": schema.StringAttribute{
+				Optional:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+				MarkdownDescription: "Write-only request body to attach to the update API call instead of `update_request_body`. It is never stored in state; change `update_wo_version` to send a new value. Requires Terraform 1.11 or later",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("update_request_body")),
+				},
+			},
+			"update_headers_wo": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+				MarkdownDescription: "Write-only map of headers to attach to the update API call. They are never stored in state and take precedence over `update_headers`. Requires Terraform 1.11 or later",
+			},
+			"update_form_body": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyDescription, "update", "`update_request_body`, `update_request_body_wo`, `update_multipart` and `update_request_body_file`"),
+			},
+			"update_form_body_values": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(formBodyValuesDescription, "update", "update_form_body"),
+			},
+			"update_request_body_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf(requestBodyFileDescription, "update", "update_request_body_file_sha256", "`update_request_body`, `update_request_body_wo`, `update_multipart`, `update_form_body` and `update_form_body_values`"),
+			},
+			"update_request_body_file_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf(requestBodyFileSHA256Description, "update_request_body_file", "an update, which sends the update call"),
+				PlanModifiers: []planmodifier.String{
+					requestBodyFileHash(path.Root("update_request_body_file")),
+				},
+			},
+			"update_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of `update_headers_wo` and `update_request_body_wo`. Changing it sends the update call with their current values",
+			},
+			"update_headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Map of headers to attach to the update API call",
+			},
+			"update_request_parameters": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Map of parameters to attach to the update API call",
+			},
+			"update_response_codes": schema.ListAttribute{
+				Optional:            true,
+				MarkdownDescription: "A list of expected response codes for the update call. Supports exact codes (`200`), classes (`2xx`), inclusive ranges (`200-299`) and negations (`!409`).",
+				ElementType:         types.StringType,
+				Validators:          validResponseCodes(),
+			},
+			"wait_for_response": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Final response received from the `wait_for` status endpoint",
+			},
+			"idempotency_key": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Set this to true to send an idempotency key with every attempt of the create, update and destroy requests, so that a retried request is not performed twice. Each create generates a new random key, which is kept in private state and reused across retries of the create request. Update and destroy requests use keys derived from it and from the request, including the content of `multipart` files and a hash of write-only bodies. Every update request gets a new key, even when it repeats an earlier one. Defaults to false",
+			},
+			"optimistic_locking": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Set this to true to make update and destroy requests conditional on the remote object being unchanged. The `ETag` and `Last-Modified` headers of the create, read and update responses are kept in private state and sent as `If-Match` and `If-Unmodified-Since`. A 412 Precondition Failed response is reported as a conflict. Defaults to false",
+			},
+			"skip_conditional_read": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Set this to true to always download the full read response. Otherwise a GET or HEAD read request sends `If-None-Match` and `If-Modified-Since` with the `ETag` and `Last-Modified` headers of the previous read response, and a 304 Not Modified response is treated as no drift. Defaults to false",
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Set this to true to send the read request before creating. If it returns one of `read_response_codes`, the existing object is adopted into state and the create request is not sent. Requires `read_url`, `read_method` and `read_response_codes`. Defaults to false",
+			},
+			"adopt_on_response_codes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Create response codes that mean the object already exists, e.g. `409`. They are not retried; the object is read with the read request and adopted into state instead. Requires `read_url`, `read_method` and `read_response_codes`.",
+				Validators:          validResponseCodes(),
+			},
+			"on_create_failure": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "What to do when the create request returned a success (`2xx`) status but failed afterwards, i.e. the status was not one of `response_codes`, an `assert` failed or its `wait_for` polling did not succeed. Any other failure, such as a `4xx` response, means the object was not created and is only reported. `error` only reports the failure, `taint` also saves the resource to state as tainted so that it is replaced on the next apply, and `destroy` sends the destroy request to roll the create back. If the rollback fails, the resource is saved as tainted. Defaults to `error`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(createFailureError, createFailureTaint, createFailureDestroy),
+				},
+			},
+			"idempotency_key_header": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the header carrying the idempotency key. A value set for this header in `headers`, `update_headers` or `destroy_headers` takes precedence. Defaults to `Idempotency-Key`",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"assert":               assertionResourceBlock("create"),
+			"read_assert":          assertionResourceBlock("read"),
+			"destroy_assert":       assertionResourceBlock("destroy"),
+			"update_assert":        assertionResourceBlock("update"),
+			"wait_for":             waitForResourceBlock(),
+			"retry_policy":         retryPolicyResourceBlock("create and update"),
+			"read_retry_policy":    retryPolicyResourceBlock("read"),
+			"destroy_retry_policy": retryPolicyResourceBlock("destroy"),
+			"multipart":            multipartResourceBlock("create", "request_body", objectRequiresReplace()),
+			"update_multipart":     multipartResourceBlock("update", "update_request_body"),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Read:              true,
+				Update:            true,
+				Delete:            true,
+				CreateDescription: fmt.Sprintf(timeoutDescription, "create"),
+				ReadDescription:   fmt.Sprintf(timeoutDescription, "read"),
+				UpdateDescription: fmt.Sprintf(timeoutDescription, "update"),
+				DeleteDescription: fmt.Sprintf(timeoutDescription, "destroy"),
+			}),
 		},
 	}
 }
@@ -412,18 +744,20 @@ func (r *CurlResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.retryPolicy = data.RetryPolicy
+	r.logging = data.Logging
 }
 
 func (r *CurlResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -435,6 +769,14 @@ func (r *CurlResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, createTimeout)
+	defer cancel()
+
 	if !data.SkipRead.IsNull() && !data.SkipRead.ValueBool() {
 		tflog.Debug(ctx, "skip_read validation triggered", map[string]interface{}{
 			"skip_read_is_null":   data.SkipRead.IsNull(),
@@ -469,44 +811,26 @@ func (r *CurlResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	data.Id = types.StringValue(data.Name.ValueString())
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, &data)...)
 
-	// useTLS is used to decide
-	useTLS := !data.CertFile.IsNull() || !data.KeyFile.IsNull() || !data.CaCertFile.IsNull() || !data.CaCertDirectory.IsNull()
-
-	var client *http.Client
-	var err error
-
-	if useTLS {
-		// Build TLS Config
-		tlsConfig := &TlsConfig{
-			CertFile:        data.CertFile.ValueString(),
-			KeyFile:         data.KeyFile.ValueString(),
-			CaCertFile:      data.CaCertFile.ValueString(),
-			CaCertDirectory: data.CaCertDirectory.ValueString(),
-			SkipTlsVerify:   data.SkipTlsVerify.ValueBool(),
-		}
-
-		// Validate TLS settings
-		if tlsConfig.CertFile != "" && tlsConfig.KeyFile == "" {
-			resp.Diagnostics.AddError("Validation Error", "`key_file` must be set if `cert_file` is set.")
-			return
-		}
+	if !data.CertFile.IsNull() && data.KeyFile.IsNull() {
+		resp.Diagnostics.AddError("Validation Error", "`key_file` must be set if `cert_file` is set.")
+		return
+	}
 
-		// Create TLS-enabled client
-		client, err = createTlsClient(tlsConfig)
-		if err != nil {
-			resp.Diagnostics.AddError("TLS Client Creation Failed", err.Error())
-			return
-		}
+	client, err := newClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddError("TLS Client Creation Failed", err.Error())
+		return
+	}
 
-	} else {
-		// Use default non-TLS client
-		client = &http.Client{
-			Timeout: 30 * time.Second,
-		}
+	writeOnly, diags := getWriteOnlyArguments(ctx, req.Config, "headers_wo", "request_body_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	reqBody := []byte(data.RequestBody.ValueString())
+	reqBody := writeOnly.body(data.RequestBody)
 	request, err := http.NewRequest(data.Method.ValueString(), data.Url.ValueString(), bytes.NewBuffer(reqBody))
 	if err != nil {
 		resp.Diagnostics.AddError("HTTP Request Creation Failed", err.Error())
@@ -521,6 +845,23 @@ func (r *CurlResource) Create(ctx context.Context, req resource.CreateRequest, r
 			}
 		}
 	}
+	writeOnly.setHeaders(request)
+
+	parts, diags := setMultipartBody(ctx, data.Multipart, request)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	form := formValues(data.FormBody, data.FormBodyValues)
+	if form != nil {
+		setFormBody(request, form)
+	}
+	_, diags = setPlannedRequestBodyFile(request, data.RequestBodyFile, &data.RequestBodyFileSha256, data.RequestBodyFileChunked)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.setRequestBodyFileHashes()...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Add query parameters
 	if !data.RequestParameters.IsNull() && !data.RequestParameters.IsUnknown() {
@@ -534,62 +875,121 @@ func (r *CurlResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 	data.RequestUrlString = types.StringValue(request.URL.String())
 
-	tflog.Debug(ctx, fmt.Sprintf("Resource create API Call: \nURL: %s\nHeaders: %s\nMethod: %s\nRequest Body: %s\n", request.URL.String(), request.Header, request.Method, data.RequestBody.ValueString()))
-	timeout := 10 * time.Second
-	if !data.Timeout.IsNull() {
-		timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
+	if data.AdoptExisting.ValueBool() {
+		adopted, diags := r.adoptExisting(ctx, &data, resp.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if adopted {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
 	}
 
-	var body []byte
-	var bodyString string
-	var statusCode int
-	retryCount := 0
-
-	for {
-		ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
-
-		response, err := client.Do(request.WithContext(ctxWithTimeout))
+	if data.IdempotencyKey.ValueBool() {
+		idempotencyKey, err := newIdempotencyKey()
 		if err != nil {
-			if retryCount < int(data.MaxRetry.ValueInt64()) {
-				retryCount++
-				time.Sleep(time.Duration(data.RetryInterval.ValueInt64()) * time.Second)
-				continue
-			}
-			resp.Diagnostics.AddError("Request Failed", err.Error())
+			resp.Diagnostics.AddError("Idempotency Key Error", err.Error())
 			return
 		}
+		setIdempotencyKey(request, data.IdempotencyKeyHeader.ValueString(), idempotencyKey)
+		resp.Diagnostics.Append(setPrivateJSON(ctx, resp.Private, idempotencyKeyPrivateKey, idempotencyKey)...)
+	}
 
-		defer func(Body io.ReadCloser) {
-			err := Body.Close()
-			if err != nil {
-				return
-			}
-		}(response.Body)
-		body, _ = io.ReadAll(response.Body)
-		statusCode = response.StatusCode
+	loggedBody := writeOnly.loggedBody(data.RequestBody)
+	if parts != nil {
+		loggedBody = parts.describe()
+	}
+	if form != nil {
+		loggedBody = form.Encode()
+	}
+	loggedBody = loggedFileOrBody(data.RequestBodyFile, loggedBody)
+	ctx = r.logging.withMasking(ctx, request, loggedBody, writeOnly.secrets()...)
+	r.logging.logRequest(ctx, "Create", request, loggedBody)
+	timeout := 10 * time.Second
+	if !data.Timeout.IsNull() {
+		timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
+	}
 
-		bodyString = string(body)
-		if bodyString == "" {
-			bodyString = "{}"
+	var responseCodes []string
+	for _, v := range data.ResponseCodes.Elements() {
+		if strVal, ok := v.(types.String); ok {
+			responseCodes = append(responseCodes, strVal.ValueString())
 		}
+	}
 
-		var responseCodes []string
-		for _, v := range data.ResponseCodes.Elements() {
-			if strVal, ok := v.(types.String); ok {
-				responseCodes = append(responseCodes, strVal.ValueString())
-			}
+	var adoptCodes []string
+	for _, v := range data.AdoptOnResponseCodes.Elements() {
+		if strVal, ok := v.(types.String); ok {
+			adoptCodes = append(adoptCodes, strVal.ValueString())
 		}
+	}
+
+	assertions, diags := assertionsFromList(ctx, data.Assert)
+	resp.Diagnostics.Append(diags...)
+	policy, diags := retryPolicyFromObject(ctx, data.RetryPolicy, r.retryPolicy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		if responseCodeChecker(responseCodes, strconv.Itoa(statusCode)) {
-			break
+	result, err := executeRequest(ctx, client, request, requestOptions{
+		Operation:                 "Create",
+		MaxRetry:                  int(data.MaxRetry.ValueInt64()),
+		RetryInterval:             time.Duration(data.RetryInterval.ValueInt64()) * time.Second,
+		Timeout:                   timeout,
+		ResponseCodes:             responseCodes,
+		Assertions:                assertions,
+		RetryPolicy:               policy,
+		Logging:                   r.logging,
+		NonRetryableResponseCodes: adoptCodes,
+	})
+	var statusErr *unexpectedStatusError
+	if errors.As(err, &statusErr) && len(adoptCodes) > 0 && responseCodeChecker(adoptCodes, strconv.Itoa(statusErr.Result.StatusCode)) {
+		tflog.Info(ctx, fmt.Sprintf("Create request returned status code %d, adopting the existing object", statusErr.Result.StatusCode))
+		adopted, diags := r.adoptExisting(ctx, &data, resp.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !adopted {
+			resp.Diagnostics.AddError(
+				"Adopt Error",
+				fmt.Sprintf("The create request returned status code %d, which means the object already exists, but the read request did not find it. Response: %s", statusErr.Result.StatusCode, string(statusErr.Result.Body)),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	if err != nil {
+		addRequestError(&resp.Diagnostics, err)
+		if result, exists := createMayExist(err); exists {
+			r.handleCreateFailure(ctx, &data, result, resp)
 		}
+		return
+	}
+	resp.Diagnostics.Append(saveResourceVersion(ctx, resp.Private, result)...)
+
+	statusCode := result.StatusCode
+
+	waitFor, diags := waitForFromObject(ctx, data.WaitFor)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		if retryCount < int(data.MaxRetry.ValueInt64()) {
-			retryCount++
-			time.Sleep(time.Duration(data.RetryInterval.ValueInt64()) * time.Second)
-		} else {
-			resp.Diagnostics.AddError("Unexpected Response Code", fmt.Sprintf("Received status code: %d", statusCode))
+	data.WaitForResponse = types.StringNull()
+	if waitFor != nil {
+		pollResult, err := pollUntilReady(ctx, client, waitFor, result, request.URL, timeout)
+		if err != nil {
+			addWaitError(&resp.Diagnostics, err)
+			r.handleCreateFailure(ctx, &data, result, resp)
+			return
+		}
+		resp.Diagnostics.Append(data.setWaitForResponse(ctx, pollResult.Body)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
@@ -597,10 +997,12 @@ func (r *CurlResource) Create(ctx context.Context, req resource.CreateRequest, r
 	data.DriftMarker = types.StringValue("initial")
 	data.DestroyRequestUrlString = types.StringValue(data.DestroyUrl.ValueString())
 	data.RequestUrlString = types.StringValue(request.URL.String())
-	data.Response = types.StringValue(bodyString)
+	resp.Diagnostics.Append(data.setResponse(ctx, result.Body)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	data.StatusCode = types.StringValue(strconv.Itoa(statusCode))
-	diags := resp.State.Set(ctx, &data)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *CurlResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -611,6 +1013,15 @@ func (r *CurlResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, &data)...)
+
+	readTimeout, diags := data.Timeouts.Read(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, readTimeout)
+	defer cancel()
 
 	// Skip read if configured
 	if data.SkipRead.ValueBool() {
@@ -626,84 +1037,77 @@ func (r *CurlResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// ======= Build TLS Client if `read_*` TLS Arguments Provided =======
-	var client *http.Client
-	useReadTls := !data.ReadCertFile.IsNull() || !data.ReadKeyFile.IsNull() || !data.ReadCaCertFile.IsNull()
-
-	if useReadTls {
-		tflog.Debug(ctx, "Using custom TLS client for Read() operation")
-
-		readTlsConfig := &TlsConfig{
-			CertFile:        data.ReadCertFile.ValueString(),
-			KeyFile:         data.ReadKeyFile.ValueString(),
-			CaCertFile:      data.ReadCaCertFile.ValueString(),
-			CaCertDirectory: data.ReadCaCertDirectory.ValueString(),
-			SkipTlsVerify:   data.ReadSkipTlsVerify.ValueBool(),
-		}
+	client, request, err := newReadRequest(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Read Error", err.Error())
+		return
+	}
 
-		tlsClient, err := createTlsClient(readTlsConfig)
-		if err != nil {
-			resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Failed to create TLS client: %s", err))
+	// ======= Conditional Read =======
+	var lastRead *readVersion
+	if !data.SkipConditionalRead.ValueBool() {
+		lastRead, diags = setConditionalReadHeaders(ctx, req.Private, request)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-		client = tlsClient
-	} else {
-		// Default non-TLS client
-		tflog.Debug(ctx, "Using default HTTP client for Read() operation")
-		client = &http.Client{Timeout: 30 * time.Second}
 	}
 
-	// ======= Build Read Request =======
-	var reqBody io.Reader = nil
-	if !data.ReadRequestBody.IsNull() && !data.ReadRequestBody.IsUnknown() {
-		reqBody = bytes.NewBuffer([]byte(data.ReadRequestBody.ValueString()))
-	}
+	// ======= Execute Request =======
+	readBody := loggedFileOrBody(data.ReadRequestBodyFile, formOrBody(data.ReadRequestBody, data.ReadFormBody, data.ReadFormBodyValues))
+	ctx = r.logging.withMasking(ctx, request, readBody)
+	r.logging.logRequest(ctx, "Read", request, readBody)
 
-	request, err := http.NewRequest(data.ReadMethod.ValueString(), data.ReadUrl.ValueString(), reqBody)
-	if err != nil {
-		resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Failed to create request: %s", err))
+	readOptions, diags := r.readRequestOptions(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	readOptions.AcceptNotModified = lastRead != nil
 
-	// ======= Add Headers =======
-	if !data.ReadHeaders.IsNull() && !data.ReadHeaders.IsUnknown() {
-		for k, v := range data.ReadHeaders.Elements() {
-			if strVal, ok := v.(types.String); ok {
-				request.Header.Set(k, strVal.ValueString())
-			}
-		}
-	}
+	result, err := executeRequest(ctx, client, request, readOptions)
+	if err != nil {
+		var statusErr *unexpectedStatusError
+		var assertErr *assertionError
+		var interruptErr *interruptedError
 
-	// ======= Add Query Parameters =======
-	if !data.ReadParameters.IsNull() && !data.ReadParameters.IsUnknown() {
-		params := request.URL.Query()
-		for k, v := range data.ReadParameters.Elements() {
-			if strVal, ok := v.(types.String); ok {
-				params.Add(k, strVal.ValueString())
-			}
+		switch {
+		case errors.As(err, &interruptErr):
+			resp.Diagnostics.AddError("Read Interrupted", interruptErr.Error())
+			return
+		case errors.As(err, &assertErr):
+			resp.Diagnostics.AddError("Read Error", assertErr.Error())
+			return
+		case errors.As(err, &statusErr):
+			// An unexpected status code, e.g. a 404 for a deleted object, is
+			// handled by drift detection below.
+			tflog.Warn(ctx, fmt.Sprintf("Read request returned unexpected status code %d", statusErr.Result.StatusCode))
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, readVersionPrivateKey, nil)...)
+		default:
+			resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Failed to call API: %s", err))
+			return
 		}
-		request.URL.RawQuery = params.Encode()
+	} else if lastRead != nil && result.StatusCode == http.StatusNotModified {
+		// The object is unchanged since the last full read, so there is no drift.
+		recordNotModified(ctx, request, lastRead)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	} else {
+		resp.Diagnostics.Append(saveResourceVersion(ctx, resp.Private, result)...)
+		resp.Diagnostics.Append(saveReadVersion(ctx, resp.Private, result)...)
 	}
 
-	// ======= Execute Request =======
-	tflog.Debug(ctx, fmt.Sprintf("Resource read API Call: \nURL: %s\nHeaders: %s\nMethod: %s\nRequest Body: %s\n", request.URL.String(), request.Header, request.Method, data.RequestBody.ValueString()))
-
-	httpResp, err := client.Do(request)
-	if err != nil {
-		resp.Diagnostics.AddError("Read Error", fmt.Sprintf("Failed to call API: %s", err))
+	// Read and store the response, with sensitive fields redacted
+	newResponse, sensitiveResponse, diags := redactResponseFields(ctx, string(result.Body), data.SensitiveResponseFields)
+	resp.Diagnostics.Append(diags...)
+	// Responses stored before a field was marked sensitive are redacted too,
+	// so that marking it does not show up as drift.
+	oldResponse, _, diags := redactResponseFields(ctx, data.Response.ValueString(), data.SensitiveResponseFields)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			return
-		}
-	}(httpResp.Body)
-
-	// Read and store the response
-	bodyBytes, _ := io.ReadAll(httpResp.Body)
-	newResponse := string(bodyBytes)
+	data.SensitiveResponse = sensitiveResponse
 
 	// ===== DRIFT DETECTION =====
 
@@ -721,7 +1125,7 @@ func (r *CurlResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Compare old and new sanitized responses
-	oldSanitized, err := sanitizeResponse(data.Response.ValueString(), ignoredFields)
+	oldSanitized, err := sanitizeResponse(oldResponse, ignoredFields)
 	if err != nil {
 		resp.Diagnostics.AddError("Sanitize Error", fmt.Sprintf("Failed to sanitize prior response: %s", err))
 		return
@@ -744,16 +1148,342 @@ func (r *CurlResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// newClient builds the client for the create and update requests, using the
+// TLS arguments if any are set.
+func newClient(data *CurlResourceModel) (*http.Client, error) {
+	if data.CertFile.IsNull() && data.KeyFile.IsNull() && data.CaCertFile.IsNull() && data.CaCertDirectory.IsNull() {
+		return &http.Client{}, nil
+	}
+	return createTlsClient(&TlsConfig{
+		CertFile:        data.CertFile.ValueString(),
+		KeyFile:         data.KeyFile.ValueString(),
+		CaCertFile:      data.CaCertFile.ValueString(),
+		CaCertDirectory: data.CaCertDirectory.ValueString(),
+		SkipTlsVerify:   data.SkipTlsVerify.ValueBool(),
+	})
+}
+
+// newReadRequest builds the client and request for the configured read
+// request.
+func newReadRequest(ctx context.Context, data *CurlResourceModel) (*http.Client, *http.Request, error) {
+	// ======= Build TLS Client if `read_*` TLS Arguments Provided =======
+	var client *http.Client
+	useReadTls := !data.ReadCertFile.IsNull() || !data.ReadKeyFile.IsNull() || !data.ReadCaCertFile.IsNull()
+
+	if useReadTls {
+		tflog.Debug(ctx, "Using custom TLS client for Read() operation")
+
+		readTlsConfig := &TlsConfig{
+			CertFile:        data.ReadCertFile.ValueString(),
+			KeyFile:         data.ReadKeyFile.ValueString(),
+			CaCertFile:      data.ReadCaCertFile.ValueString(),
+			CaCertDirectory: data.ReadCaCertDirectory.ValueString(),
+			SkipTlsVerify:   data.ReadSkipTlsVerify.ValueBool(),
+		}
+
+		tlsClient, err := createTlsClient(readTlsConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to create TLS client: %s", err)
+		}
+		client = tlsClient
+	} else {
+		// Default non-TLS client
+		tflog.Debug(ctx, "Using default HTTP client for Read() operation")
+		client = &http.Client{}
+	}
+
+	// ======= Build Read Request =======
+	var reqBody io.Reader = nil
+	if !data.ReadRequestBody.IsNull() && !data.ReadRequestBody.IsUnknown() {
+		reqBody = bytes.NewBuffer([]byte(data.ReadRequestBody.ValueString()))
+	}
+
+	request, err := http.NewRequest(data.ReadMethod.ValueString(), data.ReadUrl.ValueString(), reqBody)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to create request: %s", err)
+	}
+
+	// ======= Add Headers =======
+	if !data.ReadHeaders.IsNull() && !data.ReadHeaders.IsUnknown() {
+		for k, v := range data.ReadHeaders.Elements() {
+			if strVal, ok := v.(types.String); ok {
+				request.Header.Set(k, strVal.ValueString())
+			}
+		}
+	}
+
+	if form := formValues(data.ReadFormBody, data.ReadFormBodyValues); form != nil {
+		setFormBody(request, form)
+	}
+	if _, err := setRequestBodyFile(request, data.ReadRequestBodyFile, data.RequestBodyFileChunked); err != nil {
+		return nil, nil, fmt.Errorf("Failed to open the request body file: %s", err)
+	}
+
+	// ======= Add Query Parameters =======
+	if !data.ReadParameters.IsNull() && !data.ReadParameters.IsUnknown() {
+		params := request.URL.Query()
+		for k, v := range data.ReadParameters.Elements() {
+			if strVal, ok := v.(types.String); ok {
+				params.Add(k, strVal.ValueString())
+			}
+		}
+		request.URL.RawQuery = params.Encode()
+	}
+
+	return client, request, nil
+}
+
+// readRequestOptions returns how the configured read request is sent and
+// validated.
+func (r *CurlResource) readRequestOptions(ctx context.Context, data *CurlResourceModel) (requestOptions, diag.Diagnostics) {
+	timeout := 10 * time.Second
+	if !data.ReadTimeout.IsNull() {
+		timeout = time.Duration(data.ReadTimeout.ValueInt64()) * time.Second
+	}
+
+	retryInterval := 10 * time.Second
+	if !data.ReadRetryInterval.IsNull() {
+		retryInterval = time.Duration(data.ReadRetryInterval.ValueInt64()) * time.Second
+	}
+
+	var expectedCodes []string
+	for _, v := range data.ReadResponseCodes.Elements() {
+		if strVal, ok := v.(types.String); ok {
+			expectedCodes = append(expectedCodes, strVal.ValueString())
+		}
+	}
+
+	readAssertions, diags := assertionsFromList(ctx, data.ReadAssert)
+	readPolicy, policyDiags := retryPolicyFromObject(ctx, data.ReadRetryPolicy, r.retryPolicy)
+	diags.Append(policyDiags...)
+
+	return requestOptions{
+		Operation:     "Read",
+		MaxRetry:      int(data.ReadMaxRetry.ValueInt64()),
+		RetryInterval: retryInterval,
+		Timeout:       timeout,
+		ResponseCodes: expectedCodes,
+		Assertions:    readAssertions,
+		RetryPolicy:   readPolicy,
+		Logging:       r.logging,
+	}, diags
+}
+
 func (r *CurlResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data CurlResourceModel
+	var state CurlResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Computed values only change when a request is sent
+	data.RequestUrlString = state.RequestUrlString
+	data.StatusCode = state.StatusCode
+	data.DestroyRequestUrlString = state.DestroyRequestUrlString
+	data.DriftMarker = state.DriftMarker
+	resp.Diagnostics.Append(data.redactStoredResponse(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, &data)...)
+
+	// A resource imported by identity gets its request arguments from the
+	// first plan.
+	if imported, _ := req.Private.GetKey(ctx, importedByIdentityPrivateKey); len(imported) > 0 {
+		requestUrl, err := requestUrlString(&data)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid URL", err.Error())
+			return
+		}
+		data.RequestUrlString = types.StringValue(requestUrl)
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedByIdentityPrivateKey, nil)...)
+	}
+
+	resp.Diagnostics.Append(data.setRequestBodyFileHashes()...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.UpdateUrl.IsNull() {
+		tflog.Debug(ctx, "Skipping update request as update_url is not set")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	client, err := newClient(&data)
+	if err != nil {
+		resp.Diagnostics.AddError("TLS Client Creation Failed", err.Error())
+		return
+	}
+
+	writeOnly, diags := getWriteOnlyArguments(ctx, req.Config, "update_headers_wo", "update_request_body_wo")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqBody := writeOnly.body(data.UpdateRequestBody)
+	request, err := http.NewRequest(data.UpdateMethod.ValueString(), data.UpdateUrl.ValueString(), bytes.NewBuffer(reqBody))
+	if err != nil {
+		resp.Diagnostics.AddError("HTTP Request Creation Failed", err.Error())
+		return
+	}
+
+	// Add headers
+	if !data.UpdateHeaders.IsNull() && !data.UpdateHeaders.IsUnknown() {
+		for k, v := range data.UpdateHeaders.Elements() {
+			if strVal, ok := v.(types.String); ok {
+				request.Header.Set(k, strVal.ValueString())
+			}
+		}
+	}
+	writeOnly.setHeaders(request)
+
+	parts, diags := setMultipartBody(ctx, data.UpdateMultipart, request)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	form := formValues(data.UpdateFormBody, data.UpdateFormBodyValues)
+	if form != nil {
+		setFormBody(request, form)
+	}
+	_, diags = setPlannedRequestBodyFile(request, data.UpdateRequestBodyFile, &data.UpdateRequestBodyFileSha256, data.RequestBodyFileChunked)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	// Add query parameters
+	if !data.UpdateRequestParameters.IsNull() && !data.UpdateRequestParameters.IsUnknown() {
+		params := request.URL.Query()
+		for k, v := range data.UpdateRequestParameters.Elements() {
+			if strVal, ok := v.(types.String); ok {
+				params.Add(k, strVal.ValueString())
+			}
+		}
+		request.URL.RawQuery = params.Encode()
+	}
+
+	if data.IdempotencyKey.ValueBool() {
+		idempotencyKey, diags := resourceIdempotencyKey(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		sequence, diags := nextIdempotencySequence(ctx, req.Private, resp.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		keyBody, err := idempotencyKeyBody(parts, data.UpdateRequestBodyFileSha256, formOrBody(data.UpdateRequestBody, data.UpdateFormBody, data.UpdateFormBodyValues))
+		if err != nil {
+			resp.Diagnostics.AddError("Multipart Body Error", err.Error())
+			return
+		}
+		setIdempotencyKey(request, data.IdempotencyKeyHeader.ValueString(), operationIdempotencyKey(idempotencyKey, "update", sequence, request, writeOnly.keyBody(keyBody)))
+		resp.Diagnostics.Append(setPrivateJSON(ctx, resp.Private, idempotencyKeyPrivateKey, idempotencyKey)...)
+	}
+
+	if data.OptimisticLocking.ValueBool() {
+		resp.Diagnostics.Append(setPreconditionHeaders(ctx, req.Private, request)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	loggedBody := writeOnly.loggedBody(data.UpdateRequestBody)
+	if parts != nil {
+		loggedBody = parts.describe()
+	}
+	if form != nil {
+		loggedBody = form.Encode()
+	}
+	loggedBody = loggedFileOrBody(data.UpdateRequestBodyFile, loggedBody)
+	ctx = r.logging.withMasking(ctx, request, loggedBody, writeOnly.secrets()...)
+	r.logging.logRequest(ctx, "Update", request, loggedBody)
+	timeout := 10 * time.Second
+	if !data.Timeout.IsNull() {
+		timeout = time.Duration(data.Timeout.ValueInt64()) * time.Second
+	}
+
+	var responseCodes []string
+	for _, v := range data.UpdateResponseCodes.Elements() {
+		if strVal, ok := v.(types.String); ok {
+			responseCodes = append(responseCodes, strVal.ValueString())
+		}
+	}
+
+	updateAssertions, diags := assertionsFromList(ctx, data.UpdateAssert)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	policy, diags := retryPolicyFromObject(ctx, data.RetryPolicy, r.retryPolicy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := executeRequest(ctx, client, request, requestOptions{
+		Operation:                 "Update",
+		MaxRetry:                  int(data.MaxRetry.ValueInt64()),
+		RetryInterval:             time.Duration(data.RetryInterval.ValueInt64()) * time.Second,
+		Timeout:                   timeout,
+		ResponseCodes:             responseCodes,
+		Assertions:                updateAssertions,
+		RetryPolicy:               policy,
+		Logging:                   r.logging,
+		NonRetryableResponseCodes: preconditionResponseCodes(data.OptimisticLocking.ValueBool()),
+	})
+	if err != nil {
+		if failed, ok := preconditionFailed(err); ok && data.OptimisticLocking.ValueBool() {
+			addConflictError(&resp.Diagnostics, "update", failed)
+			return
+		}
+		addRequestError(&resp.Diagnostics, err)
+		return
+	}
+	resp.Diagnostics.Append(saveResourceVersion(ctx, resp.Private, result)...)
+	// The stored response no longer comes from a read.
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, readVersionPrivateKey, nil)...)
+
+	waitFor, diags := waitForFromObject(ctx, data.WaitFor)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.WaitForResponse = types.StringNull()
+	if waitFor != nil {
+		pollResult, err := pollUntilReady(ctx, client, waitFor, result, request.URL, timeout)
+		if err != nil {
+			addWaitError(&resp.Diagnostics, err)
+			return
+		}
+		resp.Diagnostics.Append(data.setWaitForResponse(ctx, pollResult.Body)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(data.setResponse(ctx, result.Body)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.StatusCode = types.StringValue(strconv.Itoa(result.StatusCode))
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -767,6 +1497,14 @@ func (r *CurlResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Skip Destroy if `skip_destroy` is true
 	if data.SkipDestroy.ValueBool() {
 		tflog.Debug(ctx, "Skipping Destroy() because skip_destroy is set to true")
@@ -782,6 +1520,66 @@ func (r *CurlResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	client, request, err := newDestroyRequest(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Destroy Error", err.Error())
+		return
+	}
+
+	if data.IdempotencyKey.ValueBool() {
+		idempotencyKey, diags := resourceIdempotencyKey(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		setIdempotencyKey(request, data.IdempotencyKeyHeader.ValueString(), operationIdempotencyKey(idempotencyKey, "destroy", 0, request, hashOrBody(data.DestroyRequestBodyFileSha256, formOrBody(data.DestroyRequestBody, data.DestroyFormBody, data.DestroyFormBodyValues))))
+	}
+
+	if data.OptimisticLocking.ValueBool() {
+		resp.Diagnostics.Append(setPreconditionHeaders(ctx, req.Private, request)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	destroyBody := loggedFileOrBody(data.DestroyRequestBodyFile, formOrBody(data.DestroyRequestBody, data.DestroyFormBody, data.DestroyFormBodyValues))
+	ctx = r.logging.withMasking(ctx, request, destroyBody)
+	r.logging.logRequest(ctx, "Destroy", request, destroyBody)
+
+	destroyOptions, diags := r.destroyRequestOptions(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := executeRequest(ctx, client, request, destroyOptions)
+	if err != nil {
+		if failed, ok := preconditionFailed(err); ok && data.OptimisticLocking.ValueBool() {
+			addConflictError(&resp.Diagnostics, "destroy", failed)
+			return
+		}
+
+		addDestroyError(&resp.Diagnostics, err)
+		return
+	}
+	tflog.Debug(ctx, "Destroy request completed successfully")
+
+	bodyBytes := result.Body
+	statusCode := result.StatusCode
+
+	data.DestroyRequestUrlString = types.StringValue(data.DestroyUrl.ValueString())
+	data.RequestUrlString = types.StringValue(request.URL.String())
+	data.Response = types.StringValue(string(bodyBytes))
+	data.StatusCode = types.StringValue(strconv.Itoa(statusCode))
+
+	// Remove Resource from State
+	resp.State.RemoveResource(ctx)
+	tflog.Debug(ctx, "Resource removed from state after successful destroy")
+}
+
+// newDestroyRequest builds the client and request for the configured destroy
+// request.
+func newDestroyRequest(ctx context.Context, data *CurlResourceModel) (*http.Client, *http.Request, error) {
 	// Build TLS Client if `destroy_*` TLS Arguments Provided
 	var client *http.Client
 	useDestroyTls := !data.DestroyCertFile.IsNull() || !data.DestroyKeyFile.IsNull() || !data.DestroyCaCertFile.IsNull()
@@ -799,14 +1597,13 @@ func (r *CurlResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 		tlsClient, err := createTlsClient(destroyTlsConfig)
 		if err != nil {
-			resp.Diagnostics.AddError("Destroy Error", fmt.Sprintf("Failed to create TLS client: %s", err))
-			return
+			return nil, nil, fmt.Errorf("Failed to create TLS client: %s", err)
 		}
 		client = tlsClient
 	} else {
 		// Default non-TLS client
 		tflog.Debug(ctx, "Using default HTTP client for Destroy() operation")
-		client = &http.Client{Timeout: 30 * time.Second}
+		client = &http.Client{}
 	}
 
 	// Build Destroy Request
@@ -817,8 +1614,7 @@ func (r *CurlResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 	request, err := http.NewRequest(data.DestroyMethod.ValueString(), data.DestroyUrl.ValueString(), reqBody)
 	if err != nil {
-		resp.Diagnostics.AddError("Destroy Error", fmt.Sprintf("Failed to create request: %s", err))
-		return
+		return nil, nil, fmt.Errorf("Failed to create request: %s", err)
 	}
 
 	// Add Headers
@@ -830,6 +1626,13 @@ func (r *CurlResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		}
 	}
 
+	if form := formValues(data.DestroyFormBody, data.DestroyFormBodyValues); form != nil {
+		setFormBody(request, form)
+	}
+	if _, err := setRequestBodyFile(request, data.DestroyRequestBodyFile, data.RequestBodyFileChunked); err != nil {
+		return nil, nil, fmt.Errorf("Failed to open the request body file: %s", err)
+	}
+
 	// Add Query Parameters
 	if !data.DestroyRequestParameters.IsNull() && !data.DestroyRequestParameters.IsUnknown() {
 		params := request.URL.Query()
@@ -841,85 +1644,75 @@ func (r *CurlResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		request.URL.RawQuery = params.Encode()
 	}
 
-	// Execute Request with Retry Logic
+	return client, request, nil
+}
+
+// destroyRequestOptions returns how the configured destroy request is sent
+// and validated.
+func (r *CurlResource) destroyRequestOptions(ctx context.Context, data *CurlResourceModel) (requestOptions, diag.Diagnostics) {
 	timeout := time.Duration(data.DestroyTimeout.ValueInt64()) * time.Second
 	retryInterval := time.Duration(data.DestroyRetryInterval.ValueInt64()) * time.Second
 	maxRetry := int(data.DestroyMaxRetry.ValueInt64())
 
-	tflog.Debug(ctx, fmt.Sprintf("Resource destroy API Call: \nURL: %s\nHeaders: %s\nMethod: %s\nRequest Body: %s\n", request.URL.String(), request.Header, request.Method, data.RequestBody.ValueString()))
-
-	var bodyBytes []byte
-	var statusCode int
-	var retryCount int
-
-	for {
-		ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
-
-		response, err := client.Do(request.WithContext(ctxWithTimeout))
-		if err != nil {
-			if retryCount < maxRetry {
-				retryCount++
-				tflog.Warn(ctx, fmt.Sprintf("Destroy request failed, retrying... Attempt %d/%d", retryCount, maxRetry))
-				time.Sleep(retryInterval)
-				continue
-			}
-			resp.Diagnostics.AddError("Destroy Error", fmt.Sprintf("Request failed: %s", err))
-			return
-		}
-
-		bodyBytes, _ = io.ReadAll(response.Body)
-		statusCode = response.StatusCode
-		err = response.Body.Close()
-		if err != nil {
-			return
-		}
-
-		var expectedCodes []string
-		for _, v := range data.DestroyResponseCodes.Elements() {
-			if strVal, ok := v.(types.String); ok {
-				expectedCodes = append(expectedCodes, strVal.ValueString())
-			}
-		}
-
-		// Validate Response Code
-		if responseCodeChecker(expectedCodes, strconv.Itoa(statusCode)) {
-			tflog.Debug(ctx, "Destroy request completed successfully")
-			break
-		} else {
-			if retryCount < maxRetry {
-				retryCount++
-				tflog.Warn(ctx, fmt.Sprintf(
-					"Destroy request returned unexpected status %d. Retrying (%d/%d)",
-					statusCode, retryCount, maxRetry,
-				))
-				time.Sleep(retryInterval)
-				continue
-			}
-			resp.Diagnostics.AddError(
-				"Destroy Error",
-				fmt.Sprintf("Unexpected response code from destroy request: %d. Response: %s", statusCode, string(bodyBytes)),
-			)
-			return
+	var expectedCodes []string
+	for _, v := range data.DestroyResponseCodes.Elements() {
+		if strVal, ok := v.(types.String); ok {
+			expectedCodes = append(expectedCodes, strVal.ValueString())
 		}
 	}
 
-	data.DestroyRequestUrlString = types.StringValue(data.DestroyUrl.ValueString())
-	data.RequestUrlString = types.StringValue(request.URL.String())
-	data.Response = types.StringValue(string(bodyBytes))
-	data.StatusCode = types.StringValue(strconv.Itoa(statusCode))
+	destroyAssertions, diags := assertionsFromList(ctx, data.DestroyAssert)
+	destroyPolicy, policyDiags := retryPolicyFromObject(ctx, data.DestroyRetryPolicy, r.retryPolicy)
+	diags.Append(policyDiags...)
+
+	return requestOptions{
+		Operation:                 "Destroy",
+		MaxRetry:                  maxRetry,
+		RetryInterval:             retryInterval,
+		Timeout:                   timeout,
+		ResponseCodes:             expectedCodes,
+		Assertions:                destroyAssertions,
+		RetryPolicy:               destroyPolicy,
+		Logging:                   r.logging,
+		NonRetryableResponseCodes: preconditionResponseCodes(data.OptimisticLocking.ValueBool()),
+	}, diags
+}
 
-	// Remove Resource from State
-	resp.State.RemoveResource(ctx)
-	tflog.Debug(ctx, "Resource removed from state after successful destroy")
+// addDestroyError reports an error returned by the destroy request.
+func addDestroyError(diags *diag.Diagnostics, err error) {
+	var statusErr *unexpectedStatusError
+	var assertErr *assertionError
+	var interruptErr *interruptedError
+
+	switch {
+	case errors.As(err, &interruptErr):
+		diags.AddError("Destroy Interrupted", interruptErr.Error())
+	case errors.As(err, &assertErr):
+		diags.AddError("Destroy Error", assertErr.Error())
+	case errors.As(err, &statusErr):
+		diags.AddError(
+			"Destroy Error",
+			fmt.Sprintf("Unexpected response code from destroy request: %d. Response: %s", statusErr.Result.StatusCode, string(statusErr.Result.Body)),
+		)
+	default:
+		diags.AddError("Destroy Error", fmt.Sprintf("Request failed: %s", err))
+	}
 }
 
 func (r *CurlResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" && req.Identity != nil {
+		r.importByIdentity(ctx, req, resp)
+		return
+	}
+	if isStructuredImportID(ctx, req.ID, &resp.State) {
+		r.importStructured(ctx, req.ID, resp)
+		return
+	}
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
 func (r CurlResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
-	return []resource.ConfigValidator{
+	validators := []resource.ConfigValidator{
 		resourcevalidator.RequiredTogether(
 			path.MatchRoot("cert_file"),
 			path.MatchRoot("key_file"),
@@ -941,7 +1734,41 @@ func (r CurlResource) ConfigValidators(ctx context.Context) []resource.ConfigVal
 			path.MatchRoot("read_method"),
 			path.MatchRoot("read_response_codes"),
 		),
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("update_url"),
+			path.MatchRoot("update_method"),
+			path.MatchRoot("update_response_codes"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("request_body"),
+			path.MatchRoot("multipart"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("request_body_wo"),
+			path.MatchRoot("multipart"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("update_request_body"),
+			path.MatchRoot("update_multipart"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("update_request_body_wo"),
+			path.MatchRoot("update_multipart"),
+		),
+	}
+
+	conflicts := formBodyConflicts("", "request_body", "request_body_wo", "multipart")
+	conflicts = append(conflicts, formBodyConflicts("read_", "request_body")...)
+	conflicts = append(conflicts, formBodyConflicts("update_", "request_body", "request_body_wo", "multipart")...)
+	conflicts = append(conflicts, formBodyConflicts("destroy_", "request_body")...)
+	conflicts = append(conflicts, bodyFileConflicts("", "request_body", "request_body_wo", "multipart", "form_body", "form_body_values")...)
+	conflicts = append(conflicts, bodyFileConflicts("read_", "request_body", "form_body", "form_body_values")...)
+	conflicts = append(conflicts, bodyFileConflicts("update_", "request_body", "request_body_wo", "multipart", "form_body", "form_body_values")...)
+	conflicts = append(conflicts, bodyFileConflicts("destroy_", "request_body", "form_body", "form_body_values")...)
+	for _, pair := range conflicts {
+		validators = append(validators, resourcevalidator.Conflicting(pair...))
 	}
+	return validators
 }
 
 func (r *CurlResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
@@ -1072,6 +1899,51 @@ func (r *CurlResource) UpgradeState(ctx context.Context) map[int64]resource.Stat
 				oldState.ReadCaCertDirectory = types.StringNull()
 				oldState.ReadSkipTlsVerify = types.BoolNull()
 				oldState.ReadResponseCodes = types.ListNull(types.StringType)
+				oldState.ReadRetryInterval = types.Int64Null()
+				oldState.ReadMaxRetry = types.Int64Null()
+				oldState.ReadTimeout = types.Int64Null()
+				oldState.ReadRetryPolicy = types.ObjectNull(retryPolicyAttrTypes)
+				oldState.Timeouts = timeouts.Value{Object: types.ObjectNull(resourceTimeoutsAttrTypes)}
+				oldState.AdoptOnResponseCodes = types.ListNull(types.StringType)
+				oldState.HeadersWo = types.MapNull(types.StringType)
+				oldState.UpdateHeadersWo = types.MapNull(types.StringType)
+				oldState.SensitiveResponseFields = types.ListNull(types.StringType)
+				oldState.SensitiveResponse = types.MapNull(types.StringType)
+
+				// Blocks introduced after v1 are not present in v0 states
+				oldState.Assert = emptyAssertions()
+				oldState.ReadAssert = emptyAssertions()
+				oldState.DestroyAssert = emptyAssertions()
+				oldState.UpdateAssert = emptyAssertions()
+				oldState.UpdateUrl = types.StringNull()
+				oldState.UpdateMethod = types.StringNull()
+				oldState.UpdateRequestBody = types.StringNull()
+				oldState.UpdateHeaders = types.MapNull(types.StringType)
+				oldState.UpdateRequestParameters = types.MapNull(types.StringType)
+				oldState.UpdateResponseCodes = types.ListNull(types.StringType)
+				oldState.WaitFor = types.ObjectNull(waitForAttrTypes)
+				oldState.WaitForResponse = types.StringNull()
+				oldState.RetryPolicy = types.ObjectNull(retryPolicyAttrTypes)
+				oldState.DestroyRetryPolicy = types.ObjectNull(retryPolicyAttrTypes)
+				oldState.Multipart = types.ObjectNull(multipartAttrTypes)
+				oldState.UpdateMultipart = types.ObjectNull(multipartAttrTypes)
+				oldState.FormBody = types.MapNull(types.StringType)
+				oldState.FormBodyValues = types.MapNull(types.ListType{ElemType: types.StringType})
+				oldState.ReadFormBody = types.MapNull(types.StringType)
+				oldState.ReadFormBodyValues = types.MapNull(types.ListType{ElemType: types.StringType})
+				oldState.UpdateFormBody = types.MapNull(types.StringType)
+				oldState.UpdateFormBodyValues = types.MapNull(types.ListType{ElemType: types.StringType})
+				oldState.DestroyFormBody = types.MapNull(types.StringType)
+				oldState.DestroyFormBodyValues = types.MapNull(types.ListType{ElemType: types.StringType})
+				oldState.RequestBodyFile = types.StringNull()
+				oldState.RequestBodyFileSha256 = types.StringNull()
+				oldState.ReadRequestBodyFile = types.StringNull()
+				oldState.ReadRequestBodyFileSha256 = types.StringNull()
+				oldState.UpdateRequestBodyFile = types.StringNull()
+				oldState.UpdateRequestBodyFileSha256 = types.StringNull()
+				oldState.DestroyRequestBodyFile = types.StringNull()
+				oldState.DestroyRequestBodyFileSha256 = types.StringNull()
+				oldState.RequestBodyFileChunked = types.BoolNull()
 
 				// Set the upgraded state
 				diags = resp.State.Set(ctx, oldState)
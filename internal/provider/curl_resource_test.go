@@ -2,7 +2,10 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	resource2 "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -11,10 +14,19 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/jarcoal/httpmock"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 	"time"
 )
@@ -533,48 +545,1178 @@ EOF
 
 }
 
+func TestAccresourceCurlReadRetries(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var readCount int
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/create",
+		httpmock.NewStringResponder(200, `{"name": "devopsrob"}`),
+	)
+	// The first read fails, which would be detected as drift without retries.
+	httpmock.RegisterResponder("GET", "https://example.com/read",
+		func(req *http.Request) (*http.Response, error) {
+			readCount++
+			if readCount == 1 {
+				return httpmock.NewStringResponse(503, "Service Unavailable"), nil
+			}
+			return httpmock.NewStringResponse(200, `{"name": "devopsrob"}`), nil
+		},
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccresourceCurlReadRetries(rName),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						if readCount < 2 {
+							return fmt.Errorf("expected the read request to be retried, it was made %d times", readCount)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccresourceCurlReadRetries(name string) string {
+	return fmt.Sprintf(`
+resource "terracurl_request" "read" {
+  name           = "%s"
+  url            = "https://example.com/create"
+  method         = "POST"
+  response_codes = ["200"]
+
+  skip_destroy = true
+  skip_read    = false
+  read_url     = "https://example.com/read"
+  read_method  = "GET"
+
+  read_response_codes = ["200"]
+  read_max_retry      = 1
+  read_retry_interval = 1
+  read_timeout        = 5
+}
+`, name)
+
+}
+
 func testAccresourceCurlTls(name, url, caCertFile, certFile, keyFile, readUrl, readCaCertFile, readCertFile, readKeyFile, destroyUrl, destroyCaCertFile, destroyCertFile, destroyKeyFile string) string {
 	return fmt.Sprintf(`
-resource "terracurl_request" "tls_test" {
+resource "terracurl_request" "tls_test" {
+  name           = "%s"
+  url            = "%s"
+  response_codes = ["200"]
+
+
+  retry_interval = 1
+  max_retry 	 	= 1
+  method         = "POST"
+  ca_cert_file     = "%s"
+  cert_file = "%s"
+  key_file  = "%s"
+
+  skip_read  	= false
+  read_url    	= "%s"
+  read_method 	= "GET"
+  read_ca_cert_file     = "%s"
+  read_cert_file = "%s"
+  read_key_file  = "%s"
+
+  read_response_codes = [
+	"200", 
+	"204"
+  ]
+
+  skip_destroy  = false
+  destroy_url    	= "%s"
+  destroy_method 	= "GET"
+  destroy_ca_cert_file     = "%s"
+  destroy_cert_file = "%s"
+  destroy_key_file  = "%s"
+
+  destroy_response_codes = [
+	"200", 
+	"204"
+  ]
+
+
+}
+`, name, url, caCertFile, certFile, keyFile, readUrl, readCaCertFile, readCertFile, readKeyFile, destroyUrl, destroyCaCertFile, destroyCertFile, destroyKeyFile)
+
+}
+
+func TestAccresourceCurlCreateTimeout(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/create",
+		httpmock.NewStringResponder(503, "Service Unavailable"),
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccresourceCurlCreateTimeout(rName),
+				ExpectError: regexp.MustCompile("Create request did not finish before the operation deadline"),
+			},
+		},
+	})
+}
+
+func testAccresourceCurlCreateTimeout(name string) string {
+	return fmt.Sprintf(`
+resource "terracurl_request" "timeout" {
+  name           = "%s"
+  url            = "https://example.com/create"
+  method         = "POST"
+  response_codes = ["200"]
+  max_retry      = 10
+  retry_interval = 30
+
+  skip_destroy = true
+
+  timeouts {
+    create = "2s"
+  }
+}
+`, name)
+
+}
+
+func TestAccresourceCurlIdempotencyKey(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var keys []string
+
+	httpmock.RegisterResponder("POST", "https://example.com/create",
+		func(req *http.Request) (*http.Response, error) {
+			keys = append(keys, req.Header.Get("X-Idempotency-Key"))
+			if len(keys) == 1 {
+				return httpmock.NewStringResponse(500, "Internal Server Error"), nil
+			}
+			return httpmock.NewStringResponse(200, `{"name": "devopsrob"}`), nil
+		},
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccresourceCurlIdempotencyKey(rName),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						if len(keys) != 2 {
+							return fmt.Errorf("expected 2 create attempts, got %d", len(keys))
+						}
+						if keys[0] == "" || keys[0] != keys[1] {
+							return fmt.Errorf("expected the same idempotency key on every attempt, got %q", keys)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccresourceCurlIdempotencyKey(name string) string {
+	return fmt.Sprintf(`
+resource "terracurl_request" "idempotent" {
+  name           = "%s"
+  url            = "https://example.com/create"
+  method         = "POST"
+  request_body   = "{\"name\": \"devopsrob\"}"
+  response_codes = ["200"]
+  max_retry      = 1
+  retry_interval = 1
+
+  idempotency_key        = true
+  idempotency_key_header = "X-Idempotency-Key"
+
+  skip_destroy = true
+}
+`, name)
+
+}
+
+func TestAccresourceCurlIdempotencyKeyRecreate(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var keys []string
+
+	httpmock.RegisterResponder("POST", "https://example.com/create",
+		func(req *http.Request) (*http.Response, error) {
+			keys = append(keys, req.Header.Get("X-Idempotency-Key"))
+			return httpmock.NewStringResponse(200, `{"name": "devopsrob"}`), nil
+		},
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	expectNewKey := func(creates int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			if len(keys) != creates {
+				return fmt.Errorf("expected %d create requests, got %d", creates, len(keys))
+			}
+			for i, key := range keys {
+				if key == "" {
+					return fmt.Errorf("expected create request %d to send an idempotency key", i+1)
+				}
+				for _, earlier := range keys[:i] {
+					if key == earlier {
+						return fmt.Errorf("expected a new idempotency key for every create, got %q", keys)
+					}
+				}
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccresourceCurlIdempotencyKeyBody(rName, "a"),
+				Check:  expectNewKey(1),
+			},
+			{
+				// Changing the body replaces the resource.
+				Config: testAccresourceCurlIdempotencyKeyBody(rName, "b"),
+				Check:  expectNewKey(2),
+			},
+			{
+				// Going back to the first configuration creates it again.
+				Config: testAccresourceCurlIdempotencyKeyBody(rName, "a"),
+				Check:  expectNewKey(3),
+			},
+		},
+	})
+}
+
+func testAccresourceCurlIdempotencyKeyBody(name string, body string) string {
+	return fmt.Sprintf(`
+resource "terracurl_request" "idempotent" {
+  name           = "%s"
+  url            = "https://example.com/create"
+  method         = "POST"
+  request_body   = "{\"name\": \"%s\"}"
+  response_codes = ["200"]
+
+  idempotency_key        = true
+  idempotency_key_header = "X-Idempotency-Key"
+
+  skip_destroy = true
+}
+`, name, body)
+
+}
+
+func TestAccresourceCurlOptimisticLocking(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var ifMatch string
+	var updateCount int
+
+	httpmock.RegisterResponder("POST", "https://example.com/create",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"name": "devopsrob"}`)
+			resp.Header.Set("ETag", `"v1"`)
+			return resp, nil
+		},
+	)
+	// Another client has changed the object, so the precondition fails.
+	httpmock.RegisterResponder("PUT", "https://example.com/update",
+		func(req *http.Request) (*http.Response, error) {
+			updateCount++
+			ifMatch = req.Header.Get("If-Match")
+			return httpmock.NewStringResponse(412, "Precondition Failed"), nil
+		},
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccresourceCurlOptimisticLocking(rName, "v1"),
+			},
+			{
+				Config:      testAccresourceCurlOptimisticLocking(rName, "v2"),
+				ExpectError: regexp.MustCompile("Conflict Detected"),
+			},
+		},
+	})
+
+	if ifMatch != `"v1"` {
+		t.Errorf("expected If-Match to be the ETag of the create response, got %q", ifMatch)
+	}
+	if updateCount != 1 {
+		t.Errorf("expected a 412 not to be retried, got %d update attempts", updateCount)
+	}
+}
+
+func testAccresourceCurlOptimisticLocking(name string, version string) string {
+	return fmt.Sprintf(`
+resource "terracurl_request" "locked" {
+  name           = "%s"
+  url            = "https://example.com/create"
+  method         = "POST"
+  response_codes = ["200"]
+  max_retry      = 2
+  retry_interval = 1
+
+  update_url            = "https://example.com/update"
+  update_method         = "PUT"
+  update_request_body   = "%s"
+  update_response_codes = ["200"]
+
+  optimistic_locking = true
+  skip_destroy       = true
+}
+`, name, version)
+
+}
+
+func TestAccresourceCurlConditionalRead(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var notModified int
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/create",
+		httpmock.NewStringResponder(200, `{"name": "devopsrob"}`),
+	)
+	httpmock.RegisterResponder("GET", "https://example.com/read",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("If-None-Match") == `"r1"` {
+				notModified++
+				return httpmock.NewStringResponse(304, ""), nil
+			}
+			resp := httpmock.NewStringResponse(200, `{"name": "devopsrob"}`)
+			resp.Header.Set("ETag", `"r1"`)
+			return resp, nil
+		},
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccresourceCurlConditionalRead(rName),
+			},
+			{
+				// Refreshing again must not detect drift from the 304.
+				Config: testAccresourceCurlConditionalRead(rName),
+			},
+		},
+	})
+
+	if notModified == 0 {
+		t.Error("expected a refresh to be answered with 304 Not Modified")
+	}
+}
+
+func testAccresourceCurlConditionalRead(name string) string {
+	return fmt.Sprintf(`
+resource "terracurl_request" "conditional" {
+  name           = "%s"
+  url            = "https://example.com/create"
+  method         = "POST"
+  response_codes = ["200"]
+
+  skip_destroy = true
+  skip_read    = false
+  read_url     = "https://example.com/read"
+  read_method  = "GET"
+
+  read_response_codes = ["200"]
+}
+`, name)
+
+}
+
+func TestAccresourceCurlAdoptExisting(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/create",
+		httpmock.NewStringResponder(200, `{"name": "devopsrob"}`),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/read",
+		httpmock.NewStringResponder(200, `{"name": "existing"}`),
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccresourceCurlAdopt(rName, "adopt_existing = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terracurl_request.adopt", "response", `{"name": "existing"}`),
+					testMockEndpointCount("POST https://example.com/create", 0),
+				),
+			},
+		},
+	})
+}
+
+func TestAccresourceCurlAdoptOnConflict(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/create",
+		httpmock.NewStringResponder(409, `{"error": "already exists"}`),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/read",
+		httpmock.NewStringResponder(200, `{"name": "existing"}`),
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccresourceCurlAdopt(rName, `adopt_on_response_codes = ["409"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terracurl_request.adopt", "response", `{"name": "existing"}`),
+					resource.TestCheckResourceAttr("terracurl_request.adopt", "status_code", "200"),
+					testMockEndpointCount("POST https://example.com/create", 1),
+				),
+			},
+		},
+	})
+}
+
+func testAccresourceCurlAdopt(name string, adopt string) string {
+	return fmt.Sprintf(`
+resource "terracurl_request" "adopt" {
+  name           = "%s"
+  url            = "https://example.com/create"
+  method         = "POST"
+  response_codes = ["200"]
+  max_retry      = 2
+  retry_interval = 1
+
+  read_url            = "https://example.com/read"
+  read_method         = "GET"
+  read_response_codes = ["200"]
+
+  skip_destroy = true
+
+  %s
+}
+`, name, adopt)
+
+}
+
+func TestAccresourceCurlCreateFailureRollback(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/create",
+		httpmock.NewStringResponder(202, `{"id": "1", "state": "failed"}`),
+	)
+	httpmock.RegisterResponder(
+		"DELETE",
+		"https://example.com/delete",
+		httpmock.NewStringResponder(204, ""),
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccresourceCurlCreateFailure(rName, "destroy"),
+				ExpectError: regexp.MustCompile("Unexpected Response Code"),
+			},
+		},
+	})
+
+	if err := testMockEndpointCount("DELETE https://example.com/delete", 1)(nil); err != nil {
+		t.Errorf("rollback request: %s", err)
+	}
+}
+
+func TestAccresourceCurlCreateFailureTaint(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/create",
+		httpmock.NewStringResponder(202, `{"id": "1", "state": "failed"}`),
+	)
+	httpmock.RegisterResponder(
+		"DELETE",
+		"https://example.com/delete",
+		httpmock.NewStringResponder(204, ""),
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccresourceCurlCreateFailure(rName, "taint"),
+				ExpectError: regexp.MustCompile("Unexpected Response Code"),
+			},
+		},
+	})
+
+	// The tainted resource is destroyed when the test cleans up.
+	if err := testMockEndpointCount("DELETE https://example.com/delete", 1)(nil); err != nil {
+		t.Errorf("destroy of tainted resource: %s", err)
+	}
+}
+
+func TestAccresourceCurlCreateFailureClientError(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	for _, mode := range []string{"destroy", "taint"} {
+		t.Run(mode, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder(
+				"POST",
+				"https://example.com/create",
+				httpmock.NewStringResponder(422, `{"error": "invalid name"}`),
+			)
+			httpmock.RegisterResponder(
+				"DELETE",
+				"https://example.com/delete",
+				httpmock.NewStringResponder(404, ""),
+			)
+			rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccresourceCurlCreateFailure(rName, mode),
+						ExpectError: regexp.MustCompile("Unexpected Response Code"),
+					},
+				},
+			})
+
+			// The object was never created, so it is neither rolled back nor
+			// saved to state and destroyed when the test cleans up.
+			if err := testMockEndpointCount("DELETE https://example.com/delete", 0)(nil); err != nil {
+				t.Errorf("destroy request: %s", err)
+			}
+		})
+	}
+}
+
+func TestAccresourceCurlUpdateAssert(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	updateResponse := `{"status": "error"}`
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/create",
+		httpmock.NewStringResponder(200, `{"status": "ok"}`),
+	)
+	httpmock.RegisterResponder("PUT", "https://example.com/update",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(200, updateResponse), nil
+		},
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccresourceCurlUpdateAssert(rName, "v1"),
+			},
+			{
+				Config:      testAccresourceCurlUpdateAssert(rName, "v2"),
+				ExpectError: regexp.MustCompile(`status: expected "ok", got "error"`),
+			},
+			{
+				PreConfig: func() {
+					updateResponse = `{"status": "ok"}`
+				},
+				Config: testAccresourceCurlUpdateAssert(rName, "v2"),
+				Check:  resource.TestCheckResourceAttr("terracurl_request.update", "response", `{"status": "ok"}`),
+			},
+		},
+	})
+}
+
+func testAccresourceCurlUpdateAssert(name string, version string) string {
+	return fmt.Sprintf(`
+resource "terracurl_request" "update" {
+  name           = "%s"
+  url            = "https://example.com/create"
+  method         = "POST"
+  response_codes = ["200"]
+
+  update_url            = "https://example.com/update"
+  update_method         = "PUT"
+  update_request_body   = "{\"version\": \"%s\"}"
+  update_response_codes = ["200"]
+
+  update_assert {
+    path   = "status"
+    equals = "ok"
+  }
+
+  skip_destroy = true
+}
+`, name, version)
+
+}
+
+func TestAccresourceCurlImport(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/read",
+		httpmock.NewStringResponder(200, `{"name": "existing"}`),
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	for name, importID := range map[string]string{
+		"json":      fmt.Sprintf(`{"name": %q, "url": "https://example.com/create", "method": "POST", "response_codes": [200], "read_url": "https://example.com/read", "read_method": "GET", "read_response_codes": [200], "skip_destroy": true}`, rName),
+		"key_value": fmt.Sprintf("name=%s,url=https://example.com/create,method=POST,response_codes=200,read_url=https://example.com/read,read_method=GET,read_response_codes=200", rName),
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:             testAccresourceCurlImport(rName),
+						ResourceName:       "terracurl_request.imported",
+						ImportState:        true,
+						ImportStateId:      importID,
+						ImportStatePersist: true,
+						ImportStateCheck: func(states []*terraform.InstanceState) error {
+							if len(states) != 1 {
+								return fmt.Errorf("expected 1 imported resource, got %d", len(states))
+							}
+							attributes := states[0].Attributes
+							if attributes["response"] != `{"name": "existing"}` || attributes["status_code"] != "200" {
+								return fmt.Errorf("unexpected response %q with status code %q", attributes["response"], attributes["status_code"])
+							}
+							return nil
+						},
+					},
+					{
+						Config:   testAccresourceCurlImport(rName),
+						PlanOnly: true,
+					},
+				},
+			})
+		})
+	}
+
+	if err := testMockEndpointCount("POST https://example.com/create", 0)(nil); err != nil {
+		t.Errorf("create request: %s", err)
+	}
+}
+
+func testAccresourceCurlImport(name string) string {
+	return fmt.Sprintf(`
+resource "terracurl_request" "imported" {
   name           = "%s"
-  url            = "%s"
+  url            = "https://example.com/create"
+  method         = "POST"
   response_codes = ["200"]
 
+  read_url            = "https://example.com/read"
+  read_method         = "GET"
+  read_response_codes = ["200"]
+}
+`, name)
 
-  retry_interval = 1
-  max_retry 	 	= 1
+}
+
+func TestAccresourceCurlIdentity(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/create",
+		httpmock.NewStringResponder(200, `{"name": "existing"}`),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/read",
+		httpmock.NewStringResponder(200, `{"name": "existing"}`),
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccresourceCurlImport(rName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("terracurl_request.imported", map[string]knownvalue.Check{
+						"id":       knownvalue.StringExact(rName),
+						"read_url": knownvalue.StringExact("https://example.com/read"),
+					}),
+				},
+			},
+			{
+				Config:          testAccresourceCurlImport(rName),
+				ResourceName:    "terracurl_request.imported",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+				// The request arguments are only known from the configuration.
+				ExpectNonEmptyPlan: true,
+				ImportPlanChecks: resource.ImportPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("terracurl_request.imported", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccresourceCurlWriteOnly(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var authorization, body string
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/create",
+		func(req *http.Request) (*http.Response, error) {
+			authorization = req.Header.Get("Authorization")
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return httpmock.NewStringResponse(200, `{"created": true}`), nil
+		},
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terracurl_request" "write_only" {
+  name           = "%s"
+  url            = "https://example.com/create"
   method         = "POST"
-  ca_cert_file     = "%s"
-  cert_file = "%s"
-  key_file  = "%s"
+  response_codes = ["200"]
 
-  skip_read  	= false
-  read_url    	= "%s"
-  read_method 	= "GET"
-  read_ca_cert_file     = "%s"
-  read_cert_file = "%s"
-  read_key_file  = "%s"
+  headers_wo = {
+    Authorization = "Bearer secret"
+  }
+  headers_wo_version = 1
 
-  read_response_codes = [
-	"200", 
-	"204"
-  ]
+  request_body_wo         = jsonencode({ password = "secret" })
+  request_body_wo_version = 1
+}
+`, rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terracurl_request.write_only", "headers_wo.%"),
+					resource.TestCheckNoResourceAttr("terracurl_request.write_only", "request_body_wo"),
+					resource.TestCheckResourceAttr("terracurl_request.write_only", "headers_wo_version", "1"),
+				),
+			},
+		},
+	})
 
-  skip_destroy  = false
-  destroy_url    	= "%s"
-  destroy_method 	= "GET"
-  destroy_ca_cert_file     = "%s"
-  destroy_cert_file = "%s"
-  destroy_key_file  = "%s"
+	if authorization != "Bearer secret" {
+		t.Errorf("expected write-only header to be sent, got %q", authorization)
+	}
+	if body != `{"password":"secret"}` {
+		t.Errorf("expected write-only body to be sent, got %q", body)
+	}
+}
 
-  destroy_response_codes = [
-	"200", 
-	"204"
-  ]
+func TestAccresourceCurlMultipart(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	uploadPath := filepath.Join(t.TempDir(), "artifact.zip")
+	if err := os.WriteFile(uploadPath, []byte("zip-content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var form *multipart.Form
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/upload",
+		func(req *http.Request) (*http.Response, error) {
+			if err := req.ParseMultipartForm(1 << 20); err != nil {
+				return httpmock.NewStringResponse(400, err.Error()), nil
+			}
+			form = req.MultipartForm
+			return httpmock.NewStringResponse(200, `{"uploaded": true}`), nil
+		},
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terracurl_request" "multipart" {
+  name           = "%s"
+  url            = "https://example.com/upload"
+  method         = "POST"
+  response_codes = ["200"]
 
+  multipart {
+    field {
+      name  = "description"
+      value = "release artifact"
+    }
+    file {
+      name         = "artifact"
+      path         = %q
+      content_type = "application/zip"
+    }
+    file {
+      name     = "metadata"
+      content  = jsonencode({ version = "1.0.0" })
+      filename = "metadata.json"
+    }
+  }
+}
+`, rName, uploadPath),
+				Check: resource.TestCheckResourceAttr("terracurl_request.multipart", "status_code", "200"),
+			},
+		},
+	})
 
+	if form == nil {
+		t.Fatal("expected a multipart request")
+	}
+	if got := form.Value["description"]; len(got) != 1 || got[0] != "release artifact" {
+		t.Errorf("unexpected description field %v", got)
+	}
+	if files := form.File["artifact"]; len(files) != 1 || files[0].Filename != "artifact.zip" || files[0].Header.Get("Content-Type") != "application/zip" {
+		t.Errorf("unexpected artifact part %v", files)
+	}
+	if files := form.File["metadata"]; len(files) != 1 || files[0].Filename != "metadata.json" {
+		t.Errorf("unexpected metadata part %v", files)
+	}
 }
-`, name, url, caCertFile, certFile, keyFile, readUrl, readCaCertFile, readCertFile, readKeyFile, destroyUrl, destroyCaCertFile, destroyCertFile, destroyKeyFile)
+
+func TestAccresourceCurlFormBody(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var contentType string
+	var form url.Values
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/oauth/token",
+		func(req *http.Request) (*http.Response, error) {
+			contentType = req.Header.Get("Content-Type")
+			if err := req.ParseForm(); err != nil {
+				return httpmock.NewStringResponse(400, err.Error()), nil
+			}
+			form = req.PostForm
+			return httpmock.NewStringResponse(200, `{"access_token": "abc"}`), nil
+		},
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terracurl_request" "form" {
+  name           = "%s"
+  url            = "https://example.com/oauth/token"
+  method         = "POST"
+  response_codes = ["200"]
+
+  form_body = {
+    grant_type = "client_credentials"
+    client_id  = "terracurl"
+  }
+  form_body_values = {
+    scope = ["read", "write"]
+  }
+}
+`, rName),
+				Check: resource.TestCheckResourceAttr("terracurl_request.form", "status_code", "200"),
+			},
+		},
+	})
+
+	if contentType != formContentType {
+		t.Errorf("unexpected Content-Type %q", contentType)
+	}
+	if got := form.Get("grant_type"); got != "client_credentials" {
+		t.Errorf("unexpected grant_type %q", got)
+	}
+	if got := form["scope"]; len(got) != 2 || got[0] != "read" || got[1] != "write" {
+		t.Errorf("unexpected scope %v", got)
+	}
+}
+
+func TestAccresourceCurlRequestBodyFile(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	dir := t.TempDir()
+	createPath := filepath.Join(dir, "create.json")
+	updatePath := filepath.Join(dir, "update.json")
+	writeFile := func(path, content string) {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sha256Hex := func(content string) string {
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+	writeFile(createPath, `{"title": "dashboard"}`)
+	writeFile(updatePath, `{"title": "dashboard v1"}`)
+
+	var created, updated string
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/dashboards",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			created = string(body)
+			return httpmock.NewStringResponse(200, `{"id": "1"}`), nil
+		},
+	)
+	httpmock.RegisterResponder(
+		"PUT",
+		"https://example.com/dashboards/1",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			updated = string(body)
+			return httpmock.NewStringResponse(200, `{"id": "1"}`), nil
+		},
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	config := fmt.Sprintf(`
+resource "terracurl_request" "file" {
+  name              = "%s"
+  url               = "https://example.com/dashboards"
+  method            = "POST"
+  request_body_file = %q
+  response_codes    = ["200"]
+
+  update_url               = "https://example.com/dashboards/1"
+  update_method            = "PUT"
+  update_request_body_file = %q
+  update_response_codes    = ["200"]
+}
+`, rName, createPath, updatePath)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terracurl_request.file", "request_body_file_sha256", sha256Hex(`{"title": "dashboard"}`)),
+					resource.TestCheckResourceAttr("terracurl_request.file", "update_request_body_file_sha256", sha256Hex(`{"title": "dashboard v1"}`)),
+				),
+			},
+			{
+				PreConfig: func() {
+					writeFile(updatePath, `{"title": "dashboard v2"}`)
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("terracurl_request.file", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("terracurl_request.file", "update_request_body_file_sha256", sha256Hex(`{"title": "dashboard v2"}`)),
+			},
+		},
+	})
+
+	if created != `{"title": "dashboard"}` {
+		t.Errorf("unexpected create body %q", created)
+	}
+	if updated != `{"title": "dashboard v2"}` {
+		t.Errorf("unexpected update body %q", updated)
+	}
+}
+
+func TestAccresourceCurlSensitiveResponseFields(t *testing.T) {
+	t.Setenv("TF_ACC", "true")
+	t.Setenv("USE_DEFAULT_CLIENT_FOR_TESTS", "true")
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com/create",
+		httpmock.NewStringResponder(200, `{"id": "key-1", "secret": "s3cr3t"}`),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/read",
+		httpmock.NewStringResponder(200, `{"id": "key-1", "secret": "s3cr3t"}`),
+	)
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terracurl_request" "sensitive" {
+  name           = "%s"
+  url            = "https://example.com/create"
+  method         = "POST"
+  response_codes = ["200"]
+
+  skip_read           = false
+  read_url            = "https://example.com/read"
+  read_method         = "GET"
+  read_response_codes = ["200"]
+
+  sensitive_response_fields = ["secret"]
+}
+`, rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terracurl_request.sensitive", "response", fmt.Sprintf(`{"id":"key-1","secret":"%s"}`, redactedValue("s3cr3t"))),
+					resource.TestCheckResourceAttr("terracurl_request.sensitive", "sensitive_response.secret", "s3cr3t"),
+				),
+			},
+		},
+	})
+}
+
+func testAccresourceCurlCreateFailure(name string, mode string) string {
+	return fmt.Sprintf(`
+resource "terracurl_request" "failure" {
+  name              = "%s"
+  url               = "https://example.com/create"
+  method            = "POST"
+  response_codes    = ["200"]
+  on_create_failure = "%s"
+
+  destroy_url            = "https://example.com/delete"
+  destroy_method         = "DELETE"
+  destroy_response_codes = ["204"]
+}
+`, name, mode)
 
 }
 
@@ -834,6 +1976,9 @@ func TestCurlResource_StateUpgrade(t *testing.T) {
 			"read_ca_cert_directory": schema.StringAttribute{Optional: true},
 			"read_skip_tls_verify":   schema.BoolAttribute{Optional: true},
 			"read_response_codes":    schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"read_retry_interval":    schema.Int64Attribute{Optional: true},
+			"read_max_retry":         schema.Int64Attribute{Optional: true},
+			"read_timeout":           schema.Int64Attribute{Optional: true},
 
 			// Destroy-related fields
 			"skip_destroy":               schema.BoolAttribute{Optional: true},
@@ -852,6 +1997,61 @@ func TestCurlResource_StateUpgrade(t *testing.T) {
 			"destroy_max_retry":          schema.Int64Attribute{Optional: true},
 			"destroy_retry_interval":     schema.Int64Attribute{Optional: true},
 			"destroy_request_url_string": schema.StringAttribute{Computed: true},
+
+			// Update-related fields
+			"update_url":                       schema.StringAttribute{Optional: true},
+			"update_method":                    schema.StringAttribute{Optional: true},
+			"update_request_body":              schema.StringAttribute{Optional: true},
+			"update_headers":                   schema.MapAttribute{ElementType: types.StringType, Optional: true},
+			"update_request_parameters":        schema.MapAttribute{ElementType: types.StringType, Optional: true},
+			"update_response_codes":            schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"wait_for_response":                schema.StringAttribute{Computed: true},
+			"idempotency_key":                  schema.BoolAttribute{Optional: true},
+			"idempotency_key_header":           schema.StringAttribute{Optional: true},
+			"optimistic_locking":               schema.BoolAttribute{Optional: true},
+			"skip_conditional_read":            schema.BoolAttribute{Optional: true},
+			"adopt_existing":                   schema.BoolAttribute{Optional: true},
+			"adopt_on_response_codes":          schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"on_create_failure":                schema.StringAttribute{Optional: true},
+			"headers_wo":                       schema.MapAttribute{ElementType: types.StringType, Optional: true, WriteOnly: true},
+			"headers_wo_version":               schema.Int64Attribute{Optional: true},
+			"request_body_wo":                  schema.StringAttribute{Optional: true, WriteOnly: true},
+			"request_body_wo_version":          schema.Int64Attribute{Optional: true},
+			"update_headers_wo":                schema.MapAttribute{ElementType: types.StringType, Optional: true, WriteOnly: true},
+			"update_request_body_wo":           schema.StringAttribute{Optional: true, WriteOnly: true},
+			"update_wo_version":                schema.Int64Attribute{Optional: true},
+			"sensitive_response_fields":        schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"sensitive_response":               schema.MapAttribute{ElementType: types.StringType, Computed: true, Sensitive: true},
+			"form_body":                        schema.MapAttribute{ElementType: types.StringType, Optional: true},
+			"form_body_values":                 schema.MapAttribute{ElementType: types.ListType{ElemType: types.StringType}, Optional: true},
+			"read_form_body":                   schema.MapAttribute{ElementType: types.StringType, Optional: true},
+			"read_form_body_values":            schema.MapAttribute{ElementType: types.ListType{ElemType: types.StringType}, Optional: true},
+			"update_form_body":                 schema.MapAttribute{ElementType: types.StringType, Optional: true},
+			"update_form_body_values":          schema.MapAttribute{ElementType: types.ListType{ElemType: types.StringType}, Optional: true},
+			"destroy_form_body":                schema.MapAttribute{ElementType: types.StringType, Optional: true},
+			"destroy_form_body_values":         schema.MapAttribute{ElementType: types.ListType{ElemType: types.StringType}, Optional: true},
+			"request_body_file":                schema.StringAttribute{Optional: true},
+			"request_body_file_sha256":         schema.StringAttribute{Computed: true},
+			"read_request_body_file":           schema.StringAttribute{Optional: true},
+			"read_request_body_file_sha256":    schema.StringAttribute{Computed: true},
+			"update_request_body_file":         schema.StringAttribute{Optional: true},
+			"update_request_body_file_sha256":  schema.StringAttribute{Computed: true},
+			"destroy_request_body_file":        schema.StringAttribute{Optional: true},
+			"destroy_request_body_file_sha256": schema.StringAttribute{Computed: true},
+			"request_body_file_chunked":        schema.BoolAttribute{Optional: true},
+		},
+		Blocks: map[string]schema.Block{
+			"assert":               assertionResourceBlock("create"),
+			"read_assert":          assertionResourceBlock("read"),
+			"destroy_assert":       assertionResourceBlock("destroy"),
+			"update_assert":        assertionResourceBlock("update"),
+			"wait_for":             waitForResourceBlock(),
+			"retry_policy":         retryPolicyResourceBlock("create and update"),
+			"read_retry_policy":    retryPolicyResourceBlock("read"),
+			"destroy_retry_policy": retryPolicyResourceBlock("destroy"),
+			"multipart":            multipartResourceBlock("create", "request_body", objectRequiresReplace()),
+			"update_multipart":     multipartResourceBlock("update", "update_request_body"),
+			"timeouts":             timeouts.BlockAll(ctx),
 		},
 	}
 
@@ -871,12 +2071,48 @@ func TestCurlResource_StateUpgrade(t *testing.T) {
 				types.StringValue("200"),
 			},
 		),
-		ResponseCodes:            types.ListValueMust(types.StringType, []attr.Value{}),
-		IgnoreResponseFields:     types.ListValueMust(types.StringType, []attr.Value{}),
-		RequestParameters:        types.MapValueMust(types.StringType, map[string]attr.Value{}),
-		DestroyHeaders:           types.MapValueMust(types.StringType, map[string]attr.Value{}),
-		DestroyRequestParameters: types.MapValueMust(types.StringType, map[string]attr.Value{}),
-		DestroyResponseCodes:     types.ListValueMust(types.StringType, []attr.Value{}),
+		ResponseCodes:                types.ListValueMust(types.StringType, []attr.Value{}),
+		IgnoreResponseFields:         types.ListValueMust(types.StringType, []attr.Value{}),
+		RequestParameters:            types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		DestroyHeaders:               types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		DestroyRequestParameters:     types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		DestroyResponseCodes:         types.ListValueMust(types.StringType, []attr.Value{}),
+		Assert:                       emptyAssertions(),
+		ReadAssert:                   emptyAssertions(),
+		DestroyAssert:                emptyAssertions(),
+		UpdateAssert:                 emptyAssertions(),
+		UpdateHeaders:                types.MapNull(types.StringType),
+		UpdateRequestParameters:      types.MapNull(types.StringType),
+		UpdateResponseCodes:          types.ListNull(types.StringType),
+		WaitFor:                      types.ObjectNull(waitForAttrTypes),
+		RetryPolicy:                  types.ObjectNull(retryPolicyAttrTypes),
+		ReadRetryPolicy:              types.ObjectNull(retryPolicyAttrTypes),
+		DestroyRetryPolicy:           types.ObjectNull(retryPolicyAttrTypes),
+		Multipart:                    types.ObjectNull(multipartAttrTypes),
+		UpdateMultipart:              types.ObjectNull(multipartAttrTypes),
+		FormBody:                     types.MapNull(types.StringType),
+		FormBodyValues:               types.MapNull(types.ListType{ElemType: types.StringType}),
+		ReadFormBody:                 types.MapNull(types.StringType),
+		ReadFormBodyValues:           types.MapNull(types.ListType{ElemType: types.StringType}),
+		UpdateFormBody:               types.MapNull(types.StringType),
+		UpdateFormBodyValues:         types.MapNull(types.ListType{ElemType: types.StringType}),
+		DestroyFormBody:              types.MapNull(types.StringType),
+		DestroyFormBodyValues:        types.MapNull(types.ListType{ElemType: types.StringType}),
+		RequestBodyFile:              types.StringNull(),
+		RequestBodyFileSha256:        types.StringNull(),
+		ReadRequestBodyFile:          types.StringNull(),
+		ReadRequestBodyFileSha256:    types.StringNull(),
+		UpdateRequestBodyFile:        types.StringNull(),
+		UpdateRequestBodyFileSha256:  types.StringNull(),
+		DestroyRequestBodyFile:       types.StringNull(),
+		DestroyRequestBodyFileSha256: types.StringNull(),
+		RequestBodyFileChunked:       types.BoolNull(),
+		Timeouts:                     timeouts.Value{Object: types.ObjectNull(resourceTimeoutsAttrTypes)},
+		AdoptOnResponseCodes:         types.ListNull(types.StringType),
+		HeadersWo:                    types.MapNull(types.StringType),
+		UpdateHeadersWo:              types.MapNull(types.StringType),
+		SensitiveResponseFields:      types.ListNull(types.StringType),
+		SensitiveResponse:            types.MapNull(types.StringType),
 	}
 
 	state := tfsdk.State{
@@ -0,0 +1,325 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ephemeralPrivateDataKey is the private data key Open stores the renew and
+// close requests under, and the open request when it may be sent again.
+const ephemeralPrivateDataKey = "requests"
+
+// ephemeralPrivateDataVersion is increased whenever ephemeralPrivateData
+// changes incompatibly.
+const ephemeralPrivateDataVersion = 1
+
+// ephemeralPrivateData is what Renew and Close need from the configuration
+// passed to Open. A request is nil when it is skipped.
+type ephemeralPrivateData struct {
+	Version        int               `json:"version"`
+	RenewInterval  int64             `json:"renew_interval"`
+	RenewSchedule  *renewSchedule    `json:"renew_schedule,omitempty"`
+	OnRenewFailure string            `json:"on_renew_failure,omitempty"`
+	Renew          *ephemeralRequest `json:"renew,omitempty"`
+	Close          *ephemeralRequest `json:"close,omitempty"`
+	// Open is only set when `on_renew_failure` is `reopen`.
+	Open *ephemeralRequest `json:"open,omitempty"`
+	// EncryptedResponses is only set when a renew or close field is a
+	// template.
+	EncryptedResponses string `json:"responses,omitempty"`
+
+	Responses *ephemeralResponses `json:"-"`
+}
+
+// ephemeralRequest describes an open, renew or close request. Its headers, query
+// parameters and body may hold credentials and are only stored encrypted.
+type ephemeralRequest struct {
+	Url              string              `json:"url"`
+	Method           string              `json:"method"`
+	ResponseCodes    []string            `json:"response_codes"`
+	TLS              *TlsConfig          `json:"tls,omitempty"`
+	MaxRetry         int64               `json:"max_retry"`
+	RetryInterval    int64               `json:"retry_interval"`
+	Timeout          int64               `json:"timeout"`
+	OperationTimeout time.Duration       `json:"operation_timeout"`
+	Assertions       []responseAssertion `json:"assertions,omitempty"`
+	RetryPolicy      *retryPolicy        `json:"retry_policy,omitempty"`
+	EncryptedSecrets string              `json:"secrets"`
+
+	Secrets ephemeralRequestSecrets `json:"-"`
+}
+
+// ephemeralRequestSecrets are the parts of a request that are encrypted in
+// private data.
+type ephemeralRequestSecrets struct {
+	Headers    map[string]string `json:"headers,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	Multipart  *multipartBody    `json:"multipart,omitempty"`
+	Form       url.Values        `json:"form,omitempty"`
+}
+
+// loggedBody returns the body as it is written to the debug log.
+func (s ephemeralRequestSecrets) loggedBody() string {
+	switch {
+	case s.Multipart != nil:
+		return s.Multipart.describe()
+	case s.Form != nil:
+		return s.Form.Encode()
+	}
+	return s.Body
+}
+
+// ephemeralTlsConfig returns the TLS settings of a request, or nil if the
+// default client is used.
+func ephemeralTlsConfig(certFile, keyFile, caCertFile, caCertDirectory types.String, skipTlsVerify types.Bool) *TlsConfig {
+	if !hasValue(certFile) && !hasValue(keyFile) && !hasValue(caCertFile) && !hasValue(caCertDirectory) {
+		return nil
+	}
+	return &TlsConfig{
+		CertFile:        certFile.ValueString(),
+		KeyFile:         keyFile.ValueString(),
+		CaCertFile:      caCertFile.ValueString(),
+		CaCertDirectory: caCertDirectory.ValueString(),
+		SkipTlsVerify:   skipTlsVerify.ValueBool(),
+	}
+}
+
+// encode encrypts the request secrets and serializes the private data.
+func (p ephemeralPrivateData) encode() ([]byte, error) {
+	for name, request := range p.requests() {
+		if request == nil {
+			continue
+		}
+		plaintext, err := json.Marshal(request.Secrets)
+		if err != nil {
+			return nil, err
+		}
+		request.EncryptedSecrets, err = encryptPrivateValue(plaintext, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p.EncryptedResponses = ""
+	if p.Responses != nil {
+		plaintext, err := json.Marshal(p.Responses)
+		if err != nil {
+			return nil, err
+		}
+		p.EncryptedResponses, err = encryptPrivateValue(plaintext, "responses")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(p)
+}
+
+// requests returns the stored requests by name.
+func (p ephemeralPrivateData) requests() map[string]*ephemeralRequest {
+	return map[string]*ephemeralRequest{"open": p.Open, "renew": p.Renew, "close": p.Close}
+}
+
+// save encodes the private data and stores it in private.
+func (p ephemeralPrivateData) save(ctx context.Context, private privateStateWriter) diag.Diagnostics {
+	var diags diag.Diagnostics
+	privateBytes, err := p.encode()
+	if err != nil {
+		diags.AddError("Error encoding private data", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, ephemeralPrivateDataKey, privateBytes)
+}
+
+// getEphemeralPrivateData reads the private data stored by Open and decrypts
+// the request secrets.
+func getEphemeralPrivateData(ctx context.Context, private privateStateReader) (*ephemeralPrivateData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	privateBytes, getDiags := private.GetKey(ctx, ephemeralPrivateDataKey)
+	diags.Append(getDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	privateData, err := decodeEphemeralPrivateData(privateBytes)
+	if err != nil {
+		diags.AddError(
+			"Invalid Private Data",
+			fmt.Sprintf("The renew and close requests saved when the ephemeral resource was opened could not be read: %s. The ephemeral resource must be opened again, e.g. by running the plan or apply again.", err),
+		)
+		return nil, diags
+	}
+	return privateData, diags
+}
+
+// decodeEphemeralPrivateData parses private data written by encode.
+func decodeEphemeralPrivateData(privateBytes []byte) (*ephemeralPrivateData, error) {
+	if len(privateBytes) == 0 {
+		return nil, errors.New("no private data was found")
+	}
+
+	var privateData ephemeralPrivateData
+	if err := json.Unmarshal(privateBytes, &privateData); err != nil {
+		return nil, fmt.Errorf("malformed private data: %s", err)
+	}
+	if privateData.Version != ephemeralPrivateDataVersion {
+		return nil, fmt.Errorf("unsupported private data version %d, expected %d", privateData.Version, ephemeralPrivateDataVersion)
+	}
+
+	for name, request := range privateData.requests() {
+		if request == nil {
+			continue
+		}
+		plaintext, err := decryptPrivateValue(request.EncryptedSecrets, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt the %s request: %s", name, err)
+		}
+		if err := json.Unmarshal(plaintext, &request.Secrets); err != nil {
+			return nil, fmt.Errorf("malformed %s request: %s", name, err)
+		}
+	}
+
+	if privateData.EncryptedResponses != "" {
+		plaintext, err := decryptPrivateValue(privateData.EncryptedResponses, "responses")
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt the stored responses: %s", err)
+		}
+		if err := json.Unmarshal(plaintext, &privateData.Responses); err != nil {
+			return nil, fmt.Errorf("malformed stored responses: %s", err)
+		}
+	}
+	return &privateData, nil
+}
+
+// newRequest builds the HTTP client and request.
+func (r *ephemeralRequest) newRequest() (*http.Client, *http.Request, error) {
+	client := &http.Client{}
+	if r.TLS != nil {
+		var err error
+		client, err = createTlsClient(r.TLS)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create TLS client: %s", err)
+		}
+	}
+
+	var reqBody io.Reader
+	if r.Secrets.Body != "" {
+		reqBody = bytes.NewBufferString(r.Secrets.Body)
+	}
+	request, err := http.NewRequest(r.Method, r.Url, reqBody)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %s", err)
+	}
+
+	for k, v := range r.Secrets.Headers {
+		request.Header.Set(k, v)
+	}
+	if r.Secrets.Multipart != nil {
+		if err := r.Secrets.Multipart.setBody(request); err != nil {
+			return nil, nil, err
+		}
+	}
+	if r.Secrets.Form != nil {
+		setFormBody(request, r.Secrets.Form)
+	}
+	if len(r.Secrets.Parameters) > 0 {
+		params := request.URL.Query()
+		for k, v := range r.Secrets.Parameters {
+			params.Add(k, v)
+		}
+		request.URL.RawQuery = params.Encode()
+	}
+	return client, request, nil
+}
+
+// options returns how the request is sent.
+func (r *ephemeralRequest) options(operation string, logging *loggingOptions) requestOptions {
+	return requestOptions{
+		Operation:     operation,
+		MaxRetry:      int(r.MaxRetry),
+		RetryInterval: time.Duration(r.RetryInterval) * time.Second,
+		Timeout:       time.Duration(r.Timeout) * time.Second,
+		ResponseCodes: r.ResponseCodes,
+		Assertions:    r.Assertions,
+		RetryPolicy:   r.RetryPolicy,
+		Logging:       logging,
+	}
+}
+
+var (
+	privateDataKeyOnce sync.Once
+	privateDataKey     []byte
+	privateDataKeyErr  error
+)
+
+// privateDataCipher returns an AES-GCM cipher with a key generated once per
+// provider process. Ephemeral private data never outlives the process that
+// opened the resource, so the key is never persisted.
+func privateDataCipher() (cipher.AEAD, error) {
+	privateDataKeyOnce.Do(func() {
+		privateDataKey = make([]byte, 32)
+		_, privateDataKeyErr = rand.Read(privateDataKey)
+	})
+	if privateDataKeyErr != nil {
+		return nil, privateDataKeyErr
+	}
+
+	block, err := aes.NewCipher(privateDataKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptPrivateValue encrypts plaintext for the given purpose, e.g. "renew".
+// The purpose must match on decryption, so values cannot be swapped.
+func encryptPrivateValue(plaintext []byte, purpose string) (string, error) {
+	aead, err := privateDataCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(purpose))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptPrivateValue reverses encryptPrivateValue. It fails for values
+// encrypted by another provider process.
+func decryptPrivateValue(value string, purpose string) ([]byte, error) {
+	aead, err := privateDataCipher()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(purpose))
+	if err != nil {
+		return nil, errors.New("the data was not encrypted by this provider process")
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEphemeralPrivateDataRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	privateData := ephemeralPrivateData{
+		Version:       ephemeralPrivateDataVersion,
+		RenewInterval: 60,
+		Renew: &ephemeralRequest{
+			Url:              "https://example.com/renew",
+			Method:           "POST",
+			ResponseCodes:    []string{"200"},
+			OperationTimeout: time.Minute,
+			Secrets: ephemeralRequestSecrets{
+				Headers:    map[string]string{"Authorization": "Bearer s3cr3t-header"},
+				Parameters: map[string]string{"token": "s3cr3t-param"},
+				Body:       `{"password": "s3cr3t-body"}`,
+			},
+		},
+	}
+
+	privateBytes, err := privateData.encode()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, secret := range []string{"s3cr3t-header", "s3cr3t-param", "s3cr3t-body"} {
+		if bytes.Contains(privateBytes, []byte(secret)) {
+			t.Errorf("expected %q to be encrypted in %s", secret, privateBytes)
+		}
+	}
+
+	decoded, diags := getEphemeralPrivateData(ctx, testPrivateState{ephemeralPrivateDataKey: privateBytes})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if decoded.Close != nil || decoded.RenewInterval != 60 {
+		t.Errorf("unexpected private data %+v", decoded)
+	}
+	if decoded.Renew.Url != "https://example.com/renew" || decoded.Renew.OperationTimeout != time.Minute {
+		t.Errorf("unexpected renew request %+v", decoded.Renew)
+	}
+	if decoded.Renew.Secrets.Headers["Authorization"] != "Bearer s3cr3t-header" || decoded.Renew.Secrets.Body != `{"password": "s3cr3t-body"}` {
+		t.Errorf("unexpected renew secrets %+v", decoded.Renew.Secrets)
+	}
+
+	_, request, err := decoded.Renew.newRequest()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if request.URL.String() != "https://example.com/renew?token=s3cr3t-param" || request.Header.Get("Authorization") != "Bearer s3cr3t-header" {
+		t.Errorf("unexpected request %s %v", request.URL, request.Header)
+	}
+}
+
+func TestEphemeralPrivateDataInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	valid, err := ephemeralPrivateData{
+		Version: ephemeralPrivateDataVersion,
+		Close:   &ephemeralRequest{Url: "https://example.com/close", Method: "DELETE"},
+	}.encode()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var tampered map[string]interface{}
+	if err := json.Unmarshal(valid, &tampered); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tampered["renew"] = tampered["close"]
+	delete(tampered, "close")
+	swapped, _ := json.Marshal(tampered)
+
+	testCases := map[string]struct {
+		private  testPrivateState
+		expected string
+	}{
+		"missing": {
+			private:  testPrivateState{"response": []byte(`{"RenewUrl": "https://example.com"}`)},
+			expected: "no private data was found",
+		},
+		"malformed": {
+			private:  testPrivateState{ephemeralPrivateDataKey: []byte(`{"version": "1"}`)},
+			expected: "malformed private data",
+		},
+		"old version": {
+			private:  testPrivateState{ephemeralPrivateDataKey: []byte(`{"version": 0}`)},
+			expected: "unsupported private data version 0",
+		},
+		"swapped requests": {
+			private:  testPrivateState{ephemeralPrivateDataKey: swapped},
+			expected: "failed to decrypt the renew request",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, diags := getEphemeralPrivateData(ctx, tc.private)
+			if !diags.HasError() {
+				t.Fatal("expected an error")
+			}
+			if diags[0].Summary() != "Invalid Private Data" || !strings.Contains(diags[0].Detail(), tc.expected) {
+				t.Errorf("unexpected diagnostic %s: %s", diags[0].Summary(), diags[0].Detail())
+			}
+		})
+	}
+}
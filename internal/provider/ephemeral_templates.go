@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const ephemeralTemplateDescription = "Supports Go templates against the latest open or renew response with a body, e.g. `{{ .Body.lease_id }}` or `{{ index .Headers \"X-Session-Id\" }}`, and against the open response as `{{ .Open.Body.lease_id }}`."
+
+// ephemeralTemplateData is the data available to templated renew and close
+// fields. The embedded response is the latest open or renew response, so a
+// renew response replaces the values that the next renew and close use.
+type ephemeralTemplateData struct {
+	responseTemplateData
+	// Open is the response of the open request.
+	Open responseTemplateData
+}
+
+// ephemeralResponses are the responses templates are rendered against. They
+// are stored encrypted in private data.
+type ephemeralResponses struct {
+	Open   *ephemeralResponse `json:"open"`
+	Latest *ephemeralResponse `json:"latest"`
+}
+
+// ephemeralResponse is a response received by Open or Renew.
+type ephemeralResponse struct {
+	Url        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// newEphemeralResponse records result, received from request.
+func newEphemeralResponse(request *http.Request, result *httpResult) *ephemeralResponse {
+	return &ephemeralResponse{
+		Url:        request.URL.String(),
+		StatusCode: result.StatusCode,
+		Header:     result.Header,
+		Body:       result.Body,
+	}
+}
+
+// setLatest makes the response to a renew request the one later renew and
+// close requests are rendered against. A response without a body, such as a
+// 204 No Content, keeps the previous response, whose values are still needed.
+func (r *ephemeralResponses) setLatest(request *http.Request, result *httpResult) {
+	if len(bytes.TrimSpace(result.Body)) == 0 {
+		return
+	}
+	r.Latest = newEphemeralResponse(request, result)
+}
+
+func (r *ephemeralResponse) templateData() responseTemplateData {
+	if r == nil {
+		return responseTemplateData{Headers: map[string]string{}}
+	}
+	requestURL, _ := url.Parse(r.Url)
+	return newResponseTemplateData(&httpResult{StatusCode: r.StatusCode, Header: r.Header, Body: r.Body}, requestURL)
+}
+
+// templateData returns the data renew and close templates are rendered
+// against.
+func (r *ephemeralResponses) templateData() ephemeralTemplateData {
+	return ephemeralTemplateData{
+		responseTemplateData: r.Latest.templateData(),
+		Open:                 r.Open.templateData(),
+	}
+}
+
+// usesTemplates reports whether any field of the request is a template, in
+// which case the responses have to be kept in private data.
+func (r *ephemeralRequest) usesTemplates() bool {
+	if r == nil {
+		return false
+	}
+	fields := []string{r.Url, r.Secrets.Body}
+	for _, value := range r.Secrets.Headers {
+		fields = append(fields, value)
+	}
+	for _, value := range r.Secrets.Parameters {
+		fields = append(fields, value)
+	}
+	for _, values := range r.Secrets.Form {
+		fields = append(fields, values...)
+	}
+	for _, field := range fields {
+		if strings.Contains(field, "{{") {
+			return true
+		}
+	}
+	return false
+}
+
+// render returns a copy of the request with its templates rendered against
+// data.
+func (r *ephemeralRequest) render(data ephemeralTemplateData) (*ephemeralRequest, error) {
+	rendered := *r
+	var err error
+
+	if rendered.Url, err = renderTemplate(r.Url, data); err != nil {
+		return nil, err
+	}
+	if rendered.Secrets.Body, err = renderTemplate(r.Secrets.Body, data); err != nil {
+		return nil, err
+	}
+	if rendered.Secrets.Headers, err = renderTemplateMap(r.Secrets.Headers, data); err != nil {
+		return nil, err
+	}
+	if rendered.Secrets.Parameters, err = renderTemplateMap(r.Secrets.Parameters, data); err != nil {
+		return nil, err
+	}
+	if rendered.Secrets.Form, err = renderTemplateValues(r.Secrets.Form, data); err != nil {
+		return nil, err
+	}
+	return &rendered, nil
+}
+
+func renderTemplateValues(values url.Values, data interface{}) (url.Values, error) {
+	if values == nil {
+		return nil, nil
+	}
+	rendered := make(url.Values, len(values))
+	for k, list := range values {
+		for _, v := range list {
+			value, err := renderTemplate(v, data)
+			if err != nil {
+				return nil, err
+			}
+			rendered.Add(k, value)
+		}
+	}
+	return rendered, nil
+}
+
+func renderTemplateMap(values map[string]string, data interface{}) (map[string]string, error) {
+	if values == nil {
+		return nil, nil
+	}
+	rendered := make(map[string]string, len(values))
+	for k, v := range values {
+		value, err := renderTemplate(v, data)
+		if err != nil {
+			return nil, err
+		}
+		rendered[k] = value
+	}
+	return rendered, nil
+}
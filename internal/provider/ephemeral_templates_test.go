@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestEphemeralRequestRender(t *testing.T) {
+	openRequest, _ := http.NewRequest(http.MethodPost, "https://example.com/sessions", nil)
+	open := newEphemeralResponse(openRequest, &httpResult{
+		StatusCode: 201,
+		Header:     http.Header{"X-Session-Id": {"session-1"}, "Location": {"/sessions/session-1"}},
+		Body:       []byte(`{"lease_id": "lease-1", "token": "t0k-1"}`),
+	})
+	renewed := newEphemeralResponse(openRequest, &httpResult{
+		StatusCode: 200,
+		Header:     http.Header{},
+		Body:       []byte(`{"lease_id": "lease-2", "token": "t0k-2"}`),
+	})
+
+	closeRequest := &ephemeralRequest{
+		Url:    "https://example.com/leases/{{ .Body.lease_id }}",
+		Method: "DELETE",
+		Secrets: ephemeralRequestSecrets{
+			Headers:    map[string]string{"Authorization": "Bearer {{ .Body.token }}", "X-Session-Id": `{{ index .Open.Headers "X-Session-Id" }}`},
+			Parameters: map[string]string{"session": "{{ .Open.Location }}"},
+			Body:       `{"lease": "{{ .Open.Body.lease_id }}"}`,
+		},
+	}
+	if !closeRequest.usesTemplates() {
+		t.Fatal("expected the request to use templates")
+	}
+	if (&ephemeralRequest{Url: "https://example.com"}).usesTemplates() {
+		t.Error("expected a static request not to use templates")
+	}
+
+	responses := &ephemeralResponses{Open: open, Latest: open}
+	rendered, err := closeRequest.render(responses.templateData())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rendered.Url != "https://example.com/leases/lease-1" || rendered.Secrets.Headers["Authorization"] != "Bearer t0k-1" {
+		t.Errorf("unexpected rendered request %+v", rendered)
+	}
+	if closeRequest.Url != "https://example.com/leases/{{ .Body.lease_id }}" {
+		t.Error("expected the stored request to be left unchanged")
+	}
+
+	responses.Latest = renewed
+	rendered, err = closeRequest.render(responses.templateData())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rendered.Url != "https://example.com/leases/lease-2" || rendered.Secrets.Headers["Authorization"] != "Bearer t0k-2" {
+		t.Errorf("expected the renew response to replace the open response, got %+v", rendered)
+	}
+	if rendered.Secrets.Headers["X-Session-Id"] != "session-1" || rendered.Secrets.Parameters["session"] != "https://example.com/sessions/session-1" || rendered.Secrets.Body != `{"lease": "lease-1"}` {
+		t.Errorf("unexpected values from the open response %+v", rendered.Secrets)
+	}
+
+	_, err = (&ephemeralRequest{Url: "https://example.com/{{ .Body.missing }}"}).render(responses.templateData())
+	if err == nil {
+		t.Error("expected an error for a missing key")
+	}
+}
+
+func TestEphemeralPrivateDataResponsesRoundTrip(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "https://example.com/open", nil)
+	response := newEphemeralResponse(request, &httpResult{StatusCode: 200, Body: []byte(`{"token": "s3cr3t"}`)})
+
+	privateBytes, err := ephemeralPrivateData{
+		Version:   ephemeralPrivateDataVersion,
+		Responses: &ephemeralResponses{Open: response, Latest: response},
+	}.encode()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	decoded, diags := getEphemeralPrivateData(context.Background(), testPrivateState{ephemeralPrivateDataKey: privateBytes})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if string(decoded.Responses.Latest.Body) != `{"token": "s3cr3t"}` || decoded.Responses.Open.Url != "https://example.com/open" {
+		t.Errorf("unexpected responses %+v", decoded.Responses)
+	}
+}
+
+func TestEphemeralResponsesSetLatestWithoutBody(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "https://example.com/leases/lease-1/renew", nil)
+	open := newEphemeralResponse(request, &httpResult{StatusCode: 201, Body: []byte(`{"lease_id": "lease-1"}`)})
+	responses := &ephemeralResponses{Open: open, Latest: open}
+
+	responses.setLatest(request, &httpResult{StatusCode: 204, Header: http.Header{}})
+	if responses.Latest != open {
+		t.Fatalf("expected a renew response without a body to keep the previous response, got %+v", responses.Latest)
+	}
+
+	closeRequest := &ephemeralRequest{Url: "https://example.com/leases/{{ .Body.lease_id }}", Method: "DELETE"}
+	rendered, err := closeRequest.render(responses.templateData())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rendered.Url != "https://example.com/leases/lease-1" {
+		t.Errorf("unexpected rendered url %s", rendered.Url)
+	}
+
+	responses.setLatest(request, &httpResult{StatusCode: 200, Body: []byte(`{"lease_id": "lease-2"}`)})
+	if string(responses.Latest.Body) != `{"lease_id": "lease-2"}` {
+		t.Errorf("expected a renew response with a body to replace the previous response, got %+v", responses.Latest)
+	}
+}
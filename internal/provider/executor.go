@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultRequestTimeout = 10 * time.Second
+
+// httpResult holds the outcome of a single HTTP call.
+type httpResult struct {
+	StatusCode int
+	Body       []byte
+	Header     http.Header
+	Latency    time.Duration
+}
+
+// requestOptions controls how executeRequest sends and retries a request.
+type requestOptions struct {
+	// Operation is used in log messages, e.g. "Create" or "Destroy".
+	Operation     string
+	MaxRetry      int
+	RetryInterval time.Duration
+	Timeout       time.Duration
+	ResponseCodes []string
+	Assertions    []responseAssertion
+	// RetryPolicy enables exponential backoff. If nil, attempts are spaced
+	// RetryInterval apart.
+	RetryPolicy *retryPolicy
+	// NonRetryableResponseCodes are unexpected response codes that are
+	// returned immediately, e.g. 412 when a precondition failed. They support
+	// the same patterns as ResponseCodes.
+	NonRetryableResponseCodes []string
+	// AcceptNotModified returns a 304 Not Modified response to a conditional
+	// request as is, without checking response codes or assertions.
+	AcceptNotModified bool
+	// Logging writes response bodies to the debug log when enabled.
+	Logging *loggingOptions
+}
+
+// unexpectedStatusError is returned when the last attempt received a status
+// code that is not part of the expected response codes.
+type unexpectedStatusError struct {
+	Result *httpResult
+	// NotRetryable is set when the retry policy did not allow the status code
+	// to be retried.
+	NotRetryable bool
+}
+
+func (e *unexpectedStatusError) Error() string {
+	if e.NotRetryable {
+		return fmt.Sprintf("Received status code: %d, which is not retryable. Response: %s", e.Result.StatusCode, string(e.Result.Body))
+	}
+	return fmt.Sprintf("Received status code: %d", e.Result.StatusCode)
+}
+
+// interruptedError is returned when the context is cancelled or its deadline
+// passes while a request is being sent or retried, e.g. because Terraform was
+// interrupted.
+type interruptedError struct {
+	Operation string
+	// Cause is the context error.
+	Cause error
+	// LastErr is the error of the last completed attempt, if any.
+	LastErr error
+}
+
+func (e *interruptedError) Error() string {
+	reason := "was cancelled"
+	if errors.Is(e.Cause, context.DeadlineExceeded) {
+		reason = "did not finish before the operation deadline"
+	}
+	message := fmt.Sprintf("%s request %s", e.Operation, reason)
+	if e.LastErr != nil {
+		message += fmt.Sprintf(". Last attempt: %s", e.LastErr)
+	}
+	return message
+}
+
+func (e *interruptedError) Unwrap() error {
+	return e.Cause
+}
+
+// executeRequest sends request until the response matches the expected
+// response codes and satisfies every assertion, retrying up to MaxRetry times
+// or until the retry policy's deadline. The request body is rewound before
+// each attempt.
+func executeRequest(ctx context.Context, client *http.Client, request *http.Request, opts requestOptions) (*httpResult, error) {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	start := time.Now()
+	retryCount := 0
+	for {
+		result, err := sendAttempt(ctx, client, request, timeout, retryCount)
+		if err != nil && ctx.Err() != nil {
+			// The attempt was aborted by the interrupt rather than failing.
+			return nil, &interruptedError{Operation: opts.Operation, Cause: ctx.Err()}
+		}
+		if err == nil {
+			opts.Logging.logResponse(ctx, opts.Operation, result)
+			if opts.AcceptNotModified && result.StatusCode == http.StatusNotModified {
+				return result, nil
+			}
+			if !responseCodeChecker(opts.ResponseCodes, strconv.Itoa(result.StatusCode)) {
+				err = &unexpectedStatusError{Result: result}
+			} else if failures := checkAssertions(opts.Assertions, result); len(failures) > 0 {
+				err = &assertionError{Result: result, Failures: failures}
+			} else {
+				return result, nil
+			}
+		}
+
+		if retryCount >= opts.MaxRetry {
+			return result, err
+		}
+
+		var statusErr *unexpectedStatusError
+		if errors.As(err, &statusErr) && len(opts.NonRetryableResponseCodes) > 0 && responseCodeChecker(opts.NonRetryableResponseCodes, strconv.Itoa(statusErr.Result.StatusCode)) {
+			statusErr.NotRetryable = true
+			return result, err
+		}
+
+		if opts.RetryPolicy != nil && !opts.RetryPolicy.shouldRetry(err) {
+			tflog.Debug(ctx, fmt.Sprintf("%s request failed and is not retryable: %s", opts.Operation, err))
+			if errors.As(err, &statusErr) {
+				statusErr.NotRetryable = true
+			}
+			return result, err
+		}
+
+		retryCount++
+		delay := opts.RetryInterval
+		if opts.RetryPolicy != nil {
+			delay = opts.RetryPolicy.backoff(retryCount, opts.RetryInterval, result)
+			if opts.RetryPolicy.MaxElapsedTime > 0 && time.Since(start)+delay > opts.RetryPolicy.MaxElapsedTime {
+				tflog.Warn(ctx, fmt.Sprintf("%s request did not succeed and the retry deadline of %s would be exceeded, giving up: %s", opts.Operation, opts.RetryPolicy.MaxElapsedTime, err))
+				return result, err
+			}
+		}
+
+		tflog.Warn(ctx, fmt.Sprintf("%s request did not succeed, retrying in %s (%d/%d): %s", opts.Operation, delay, retryCount, opts.MaxRetry, err))
+		if !sleepContext(ctx, delay) {
+			return nil, &interruptedError{Operation: opts.Operation, Cause: ctx.Err(), LastErr: err}
+		}
+	}
+}
+
+// sleepContext waits for d and reports whether it elapsed before ctx was
+// done.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// sendAttempt performs one HTTP call bounded by timeout and fully reads the
+// response body before returning.
+func sendAttempt(ctx context.Context, client *http.Client, request *http.Request, timeout time.Duration, attempt int) (*httpResult, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	attemptRequest := request.Clone(attemptCtx)
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		attemptRequest.Body = body
+	} else if attempt > 0 && request.Body != nil && request.Body != http.NoBody {
+		return nil, errors.New("request body cannot be sent more than once")
+	}
+
+	start := time.Now()
+	response, err := client.Do(attemptRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(response.Body)
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &httpResult{
+		StatusCode: response.StatusCode,
+		Body:       body,
+		Header:     response.Header,
+		Latency:    time.Since(start),
+	}, nil
+}
+
+// addRequestError reports an error returned by executeRequest.
+func addRequestError(diags *diag.Diagnostics, err error) {
+	var statusErr *unexpectedStatusError
+	var assertErr *assertionError
+	var interruptErr *interruptedError
+
+	switch {
+	case errors.As(err, &interruptErr):
+		diags.AddError("Request Interrupted", interruptErr.Error())
+	case errors.As(err, &assertErr):
+		diags.AddError("Assertion Failed", assertErr.Error())
+	case errors.As(err, &statusErr):
+		diags.AddError("Unexpected Response Code", statusErr.Error())
+	default:
+		diags.AddError("Request Failed", err.Error())
+	}
+}
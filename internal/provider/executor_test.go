@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExecuteRequestRetriesUntilAssertionsPass(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"test"}` {
+			t.Errorf("attempt %d received body %q", attempts, body)
+		}
+		if attempts < 3 {
+			_, _ = w.Write([]byte(`{"status":"pending"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewBufferString(`{"name":"test"}`))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	ok := "ok"
+	result, err := executeRequest(context.Background(), server.Client(), request, requestOptions{
+		Operation:     "Create",
+		MaxRetry:      3,
+		ResponseCodes: []string{"2xx"},
+		Assertions:    []responseAssertion{{Path: "status", Equals: &ok}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if string(result.Body) != `{"status":"ok"}` {
+		t.Errorf("unexpected body %q", result.Body)
+	}
+}
+
+func TestExecuteRequestReturnsLastFailure(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error":"exists"}`))
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	result, err := executeRequest(context.Background(), server.Client(), request, requestOptions{
+		Operation:     "Read",
+		MaxRetry:      1,
+		ResponseCodes: []string{"200"},
+	})
+
+	var statusErr *unexpectedStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected unexpectedStatusError, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+	if result == nil || result.StatusCode != http.StatusConflict || string(result.Body) != `{"error":"exists"}` {
+		t.Errorf("expected the last response to be returned, got %+v", result)
+	}
+}
+
+func TestExecuteRequestStopsWhenCancelled(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = executeRequest(ctx, server.Client(), request, requestOptions{
+		Operation:     "Create",
+		MaxRetry:      5,
+		RetryInterval: time.Minute,
+		ResponseCodes: []string{"200"},
+	})
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the retry wait to be interrupted, took %s", elapsed)
+	}
+
+	var interruptErr *interruptedError
+	if !errors.As(err, &interruptErr) {
+		t.Fatalf("expected an interruptedError, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the error to wrap the context error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+	if want := "Create request did not finish before the operation deadline. Last attempt: Received status code: 503"; err.Error() != want {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+}
+
+func TestExecuteRequestDoesNotRetryNonRetryableResponseCodes(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusPreconditionFailed)
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodPut, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	_, err = executeRequest(context.Background(), server.Client(), request, requestOptions{
+		Operation:                 "Update",
+		MaxRetry:                  3,
+		ResponseCodes:             []string{"200"},
+		NonRetryableResponseCodes: []string{"412"},
+	})
+	if _, ok := preconditionFailed(err); !ok {
+		t.Fatalf("expected a 412 error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestExecuteRequestAcceptsNotModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	exists := true
+	result, err := executeRequest(context.Background(), server.Client(), request, requestOptions{
+		Operation:         "Read",
+		ResponseCodes:     []string{"200"},
+		Assertions:        []responseAssertion{{Path: "name", Exists: &exists}},
+		AcceptNotModified: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.StatusCode != http.StatusNotModified {
+		t.Errorf("unexpected status code %d", result.StatusCode)
+	}
+}
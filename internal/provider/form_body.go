@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const formContentType = "application/x-www-form-urlencoded"
+
+const (
+	formBodyDescription       = "Map of form fields sent as the %s request body, encoded as `" + formContentType + "`. The `Content-Type` header is set unless it is configured. Conflicts with %s."
+	formBodyValuesDescription = "Map of form fields with several values, e.g. `scope = [\"read\", \"write\"]`, sent as the %s request body together with `%s`."
+)
+
+// formValues merges a `*form_body` map and a `*form_body_values` map of
+// lists. It returns nil if neither is set.
+func formValues(fields types.Map, listFields types.Map) url.Values {
+	if !isKnownMap(fields) && !isKnownMap(listFields) {
+		return nil
+	}
+
+	values := url.Values{}
+	for k, v := range convertMap(fields) {
+		values.Add(k, v)
+	}
+	if isKnownMap(listFields) {
+		for k, v := range listFields.Elements() {
+			list, ok := v.(types.List)
+			if !ok {
+				continue
+			}
+			values[k] = append(values[k], stringElements(list)...)
+		}
+	}
+	return values
+}
+
+func isKnownMap(m types.Map) bool {
+	return !m.IsNull() && !m.IsUnknown()
+}
+
+// setFormBody makes the encoded values the body of request and sets its
+// `Content-Type` header unless it was configured. It returns the encoded
+// body.
+func setFormBody(request *http.Request, values url.Values) string {
+	encoded := values.Encode()
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(encoded)), nil
+	}
+	request.Body, _ = request.GetBody()
+	request.ContentLength = int64(len(encoded))
+	if request.Header.Get("Content-Type") == "" {
+		request.Header.Set("Content-Type", formContentType)
+	}
+	return encoded
+}
+
+// formOrBody returns the encoded form body if one is configured, and body
+// otherwise.
+func formOrBody(body types.String, fields types.Map, listFields types.Map) string {
+	if values := formValues(fields, listFields); values != nil {
+		return values.Encode()
+	}
+	return body.ValueString()
+}
+
+// isFormRequest reports whether request has a form body.
+func isFormRequest(request *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	return err == nil && mediaType == formContentType
+}
+
+// formBodyConflicts returns the pairs of attributes of an operation, e.g.
+// "update_", that cannot be set together with its form body attributes.
+func formBodyConflicts(prefix string, bodies ...string) [][]path.Expression {
+	var pairs [][]path.Expression
+	for _, body := range bodies {
+		for _, form := range []string{"form_body", "form_body_values"} {
+			pairs = append(pairs, []path.Expression{path.MatchRoot(prefix + body), path.MatchRoot(prefix + form)})
+		}
+	}
+	return pairs
+}
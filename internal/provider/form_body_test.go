@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFormValues(t *testing.T) {
+	fields := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"grant_type": types.StringValue("client_credentials"),
+		"note":       types.StringValue("a&b c"),
+	})
+	listFields := types.MapValueMust(types.ListType{ElemType: types.StringType}, map[string]attr.Value{
+		"scope": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("read"), types.StringValue("write")}),
+	})
+
+	if got := formValues(types.MapNull(types.StringType), types.MapNull(types.ListType{ElemType: types.StringType})); got != nil {
+		t.Errorf("expected no form values, got %v", got)
+	}
+
+	got := formValues(fields, listFields).Encode()
+	if want := "grant_type=client_credentials&note=a%26b+c&scope=read&scope=write"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got := formOrBody(types.StringValue("{}"), fields, types.MapNull(types.ListType{ElemType: types.StringType})); got != "grant_type=client_credentials&note=a%26b+c" {
+		t.Errorf("unexpected body %q", got)
+	}
+}
+
+func TestSetFormBody(t *testing.T) {
+	values := formValues(types.MapValueMust(types.StringType, map[string]attr.Value{
+		"client_secret": types.StringValue("s3cr3t/value"),
+	}), types.MapNull(types.ListType{ElemType: types.StringType}))
+
+	request, _ := http.NewRequest(http.MethodPost, "https://example.com/token", nil)
+	encoded := setFormBody(request, values)
+	if request.Header.Get("Content-Type") != formContentType || request.ContentLength != int64(len(encoded)) {
+		t.Errorf("unexpected request headers %v (Content-Length %d)", request.Header, request.ContentLength)
+	}
+	for i := 0; i < 2; i++ {
+		body, _ := request.GetBody()
+		if sent, _ := io.ReadAll(body); string(sent) != encoded {
+			t.Errorf("expected body %q, got %q", encoded, sent)
+		}
+	}
+
+	var options *loggingOptions
+	secrets := strings.Join(options.secretValues(request, encoded), " ")
+	if !strings.Contains(secrets, "s3cr3t/value") || !strings.Contains(secrets, "s3cr3t%2Fvalue") {
+		t.Errorf("expected the form field to be masked, got %q", secrets)
+	}
+
+	request, _ = http.NewRequest(http.MethodPost, "https://example.com/token", nil)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	setFormBody(request, values)
+	if got := request.Header.Get("Content-Type"); got != "application/x-www-form-urlencoded; charset=utf-8" {
+		t.Errorf("expected the configured Content-Type to be kept, got %q", got)
+	}
+}
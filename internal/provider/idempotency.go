@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultIdempotencyKeyHeader = "Idempotency-Key"
+	idempotencyKeyPrivateKey    = "idempotency_key"
+	// idempotencySequencePrivateKey counts the update requests sent.
+	idempotencySequencePrivateKey = "idempotency_sequence"
+)
+
+// newIdempotencyKey returns a random UUID (version 4). Every create gets a
+// new key, so that recreating an unchanged resource, or creating several
+// resources from the same configuration, is not mistaken by the API for a
+// retry of an earlier create.
+func newIdempotencyKey() (string, error) {
+	var key [16]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return "", fmt.Errorf("failed to generate an idempotency key: %s", err)
+	}
+	key[6] = (key[6] & 0x0f) | 0x40
+	key[8] = (key[8] & 0x3f) | 0x80
+	return formatUUID(key[:]), nil
+}
+
+// deriveIdempotencyKey derives a UUID formatted key from parts. The same parts
+// always produce the same key.
+func deriveIdempotencyKey(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	// Mark the key as a name-based UUID (version 5, RFC 4122 variant).
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return formatUUID(sum[:16])
+}
+
+func formatUUID(b []byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// operationIdempotencyKey derives the key for an update or destroy request from
+// the key generated at create time and sequence, the number of the request
+// among those of its operation. Each new request gets its own key, even one
+// repeating an earlier request, while retries of the same request reuse it.
+func operationIdempotencyKey(baseKey string, operation string, sequence int64, request *http.Request, body string) string {
+	return deriveIdempotencyKey(baseKey, operation, strconv.FormatInt(sequence, 10), request.Method, request.URL.String(), body)
+}
+
+// setIdempotencyKey adds key to request unless the header was already set
+// explicitly.
+func setIdempotencyKey(request *http.Request, header string, key string) {
+	if header == "" {
+		header = defaultIdempotencyKeyHeader
+	}
+	if request.Header.Get(header) != "" {
+		return
+	}
+	request.Header.Set(header, key)
+}
+
+// resourceIdempotencyKey returns the key generated when the resource was
+// created. Resources created before the key was enabled get a new key, which
+// the caller saves for later requests.
+func resourceIdempotencyKey(ctx context.Context, private privateStateReader) (string, diag.Diagnostics) {
+	var key string
+	found, diags := getPrivateJSON(ctx, private, idempotencyKeyPrivateKey, &key)
+	if diags.HasError() || (found && key != "") {
+		return key, diags
+	}
+
+	key, err := newIdempotencyKey()
+	if err != nil {
+		diags.AddError("Idempotency Key Error", err.Error())
+	}
+	return key, diags
+}
+
+// nextIdempotencySequence returns the sequence number of a new update request
+// and saves it in private state. A request that fails before its private
+// state is saved is sent again with the same number, and so the same key.
+func nextIdempotencySequence(ctx context.Context, previous privateStateReader, private privateStateWriter) (int64, diag.Diagnostics) {
+	var sequence int64
+	_, diags := getPrivateJSON(ctx, previous, idempotencySequencePrivateKey, &sequence)
+	if diags.HasError() {
+		return 0, diags
+	}
+	sequence++
+	diags.Append(setPrivateJSON(ctx, private, idempotencySequencePrivateKey, sequence)...)
+	return sequence, diags
+}
+
+// idempotencyKeyBody returns the request body an idempotency key is derived
+// from: the digest of the multipart body if parts is set, and otherwise the
+// hash of the request body file or body.
+func idempotencyKeyBody(parts *multipartBody, fileHash types.String, body string) (string, error) {
+	if parts != nil {
+		return parts.digest()
+	}
+	return hashOrBody(fileHash, body), nil
+}
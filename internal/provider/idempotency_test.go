@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	key, err := newIdempotencyKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !uuid.MatchString(key) {
+		t.Errorf("expected a UUID formatted key, got %q", key)
+	}
+	if again, _ := newIdempotencyKey(); again == key {
+		t.Errorf("expected a new key on every call, got %q twice", key)
+	}
+}
+
+func TestOperationIdempotencyKey(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPut, "https://example.com/items/1", nil)
+
+	key := operationIdempotencyKey("base", "update", 1, request, `{"name":"a"}`)
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(key) {
+		t.Errorf("expected a UUID formatted key, got %q", key)
+	}
+	if again := operationIdempotencyKey("base", "update", 1, request, `{"name":"a"}`); again != key {
+		t.Errorf("expected a retry of the same request to reuse its key, got %q and %q", key, again)
+	}
+	if repeated := operationIdempotencyKey("base", "update", 3, request, `{"name":"a"}`); repeated == key {
+		t.Error("expected a later update repeating an earlier body to get a new key")
+	}
+	if other := operationIdempotencyKey("other", "update", 1, request, `{"name":"a"}`); other == key {
+		t.Error("expected a different key for a different resource")
+	}
+}
+
+func TestNextIdempotencySequence(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateState{}
+
+	for expected := int64(1); expected <= 3; expected++ {
+		sequence, diags := nextIdempotencySequence(ctx, private, private)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if sequence != expected {
+			t.Errorf("expected sequence %d, got %d", expected, sequence)
+		}
+	}
+}
+
+func TestSetIdempotencyKey(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	setIdempotencyKey(request, "", "generated")
+	if got := request.Header.Get("Idempotency-Key"); got != "generated" {
+		t.Errorf("expected the default header to be set, got %q", got)
+	}
+
+	request, _ = http.NewRequest(http.MethodPost, "https://example.com", nil)
+	request.Header.Set("X-Request-Key", "explicit")
+	setIdempotencyKey(request, "X-Request-Key", "generated")
+	if got := request.Header.Get("X-Request-Key"); got != "explicit" {
+		t.Errorf("expected an explicit header to take precedence, got %q", got)
+	}
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// importedByIdentityPrivateKey marks a resource imported by identity. Its
+// request arguments are unknown until the first apply.
+const importedByIdentityPrivateKey = "imported_by_identity"
+
+// CurlResourceIdentityModel describes the resource identity data model.
+type CurlResourceIdentityModel struct {
+	Id      types.String `tfsdk:"id"`
+	ReadUrl types.String `tfsdk:"read_url"`
+}
+
+func (r *CurlResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Identifier of the resource, the value of `name`",
+			},
+			"read_url": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "URL the remote object is read from",
+			},
+		},
+	}
+}
+
+// setResourceIdentity sets identity from data. Clients without identity
+// support leave identity nil.
+func setResourceIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, data *CurlResourceModel) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, CurlResourceIdentityModel{
+		Id:      data.Id,
+		ReadUrl: data.ReadUrl,
+	})
+}
+
+// importByIdentity imports the object at the read_url of the identity with a
+// GET request. The remaining arguments are taken from the configuration on
+// the next apply.
+func (r *CurlResource) importByIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity CurlResourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, diags := importValues(ctx, map[string]interface{}{
+		"id":                  identity.Id.ValueString(),
+		"name":                identity.Id.ValueString(),
+		"read_url":            identity.ReadUrl.ValueString(),
+		"read_method":         "GET",
+		"read_response_codes": []interface{}{"2xx"},
+	}, &resp.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setImportDefaults(&data)
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedByIdentityPrivateKey, []byte("true"))...)
+	r.importRead(ctx, &data, resp)
+}
+
+// requestUrlString returns the create request URL including
+// request_parameters, as stored in request_url_string.
+func requestUrlString(data *CurlResourceModel) (string, error) {
+	requestUrl, err := url.Parse(data.Url.ValueString())
+	if err != nil {
+		return "", err
+	}
+	if !data.RequestParameters.IsNull() && !data.RequestParameters.IsUnknown() {
+		params := requestUrl.Query()
+		for k, v := range data.RequestParameters.Elements() {
+			if strVal, ok := v.(types.String); ok {
+				params.Add(k, strVal.ValueString())
+			}
+		}
+		requestUrl.RawQuery = params.Encode()
+	}
+	return requestUrl.String(), nil
+}
+
+const requiresReplaceDescription = "Changing this value replaces the resource, unless it was imported by identity and the value has not been set yet."
+
+// requiresReplaceUnlessImported replaces the resource when the value changes,
+// except when a resource imported by identity gets its first value from the
+// configuration.
+func requiresReplaceUnlessImported(ctx context.Context, stateValue attr.Value, private privateStateReader) bool {
+	if !stateValue.IsNull() {
+		return true
+	}
+	imported, _ := private.GetKey(ctx, importedByIdentityPrivateKey)
+	return len(imported) == 0
+}
+
+func stringRequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = requiresReplaceUnlessImported(ctx, req.StateValue, req.Private)
+	}, requiresReplaceDescription, requiresReplaceDescription)
+}
+
+func mapRequiresReplace() planmodifier.Map {
+	return mapplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = requiresReplaceUnlessImported(ctx, req.StateValue, req.Private)
+	}, requiresReplaceDescription, requiresReplaceDescription)
+}
+
+func int64RequiresReplace() planmodifier.Int64 {
+	return int64planmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = requiresReplaceUnlessImported(ctx, req.StateValue, req.Private)
+	}, requiresReplaceDescription, requiresReplaceDescription)
+}
+
+func objectRequiresReplace() planmodifier.Object {
+	return objectplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = requiresReplaceUnlessImported(ctx, req.StateValue, req.Private)
+	}, requiresReplaceDescription, requiresReplaceDescription)
+}
+
+func boolRequiresReplace() planmodifier.Bool {
+	return boolplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = requiresReplaceUnlessImported(ctx, req.StateValue, req.Private)
+	}, requiresReplaceDescription, requiresReplaceDescription)
+}
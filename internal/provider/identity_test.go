@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRequiresReplaceUnlessImported(t *testing.T) {
+	ctx := context.Background()
+	imported := testPrivateState{importedByIdentityPrivateKey: []byte("true")}
+
+	tests := []struct {
+		name       string
+		stateValue types.String
+		private    testPrivateState
+		expected   bool
+	}{
+		{name: "Changed value", stateValue: types.StringValue("https://example.com/a"), private: testPrivateState{}, expected: true},
+		{name: "Value set after create", stateValue: types.StringNull(), private: testPrivateState{}, expected: true},
+		{name: "Changed value after identity import", stateValue: types.StringValue("https://example.com/a"), private: imported, expected: true},
+		{name: "First value after identity import", stateValue: types.StringNull(), private: imported, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requiresReplaceUnlessImported(ctx, tt.stateValue, tt.private); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRequestUrlString(t *testing.T) {
+	data := CurlResourceModel{
+		Url:               types.StringValue("https://example.com/create?a=1"),
+		RequestParameters: types.MapValueMust(types.StringType, map[string]attr.Value{"b": types.StringValue("2")}),
+	}
+
+	got, err := requestUrlString(&data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "https://example.com/create?a=1&b=2" {
+		t.Errorf("unexpected URL %s", got)
+	}
+}
@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// isStructuredImportID reports whether id is a JSON object or a list of
+// key=value pairs rather than a plain identifier. An ID only counts as a list
+// of pairs if every key names an attribute of the schema of state, so that
+// identifiers such as base64 strings ending in "=" are still passed through.
+func isStructuredImportID(ctx context.Context, id string, state *tfsdk.State) bool {
+	id = strings.TrimSpace(id)
+	if strings.HasPrefix(id, "{") {
+		return true
+	}
+	if !strings.Contains(id, "=") {
+		return false
+	}
+
+	for _, pair := range strings.Split(id, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, _, found := strings.Cut(pair, "=")
+		name, _, _ := strings.Cut(strings.TrimSpace(key), ".")
+		if !found || name == "" {
+			return false
+		}
+		if _, diags := state.Schema.AttributeAtPath(ctx, path.Root(name)); diags.HasError() {
+			return false
+		}
+	}
+	return true
+}
+
+// parseImportID decodes a structured import ID into attribute values. Two
+// formats are accepted:
+//
+//   - a JSON object, e.g. {"name": "x", "read_response_codes": ["200"]}
+//   - comma separated key=value pairs, e.g. name=x,read_response_codes=200.
+//     Repeating a key adds list elements and a dotted key, e.g.
+//     read_headers.Accept=application/json, sets a map element.
+func parseImportID(id string) (map[string]interface{}, error) {
+	id = strings.TrimSpace(id)
+	values := map[string]interface{}{}
+
+	if strings.HasPrefix(id, "{") {
+		decoder := json.NewDecoder(bytes.NewBufferString(id))
+		decoder.UseNumber()
+		if err := decoder.Decode(&values); err != nil {
+			return nil, fmt.Errorf("invalid JSON import ID: %s", err)
+		}
+		return values, nil
+	}
+
+	for _, pair := range strings.Split(id, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid import ID element %q, expected key=value", pair)
+		}
+
+		if name, mapKey, isMap := strings.Cut(key, "."); isMap {
+			elements, ok := values[name].(map[string]interface{})
+			if !ok {
+				if _, exists := values[name]; exists {
+					return nil, fmt.Errorf("import ID key %q is used both as a map and as a value", name)
+				}
+				elements = map[string]interface{}{}
+				values[name] = elements
+			}
+			elements[mapKey] = value
+			continue
+		}
+
+		switch existing := values[key].(type) {
+		case nil:
+			values[key] = value
+		case string:
+			values[key] = []interface{}{existing, value}
+		case []interface{}:
+			values[key] = append(existing, value)
+		default:
+			return nil, fmt.Errorf("import ID key %q is used both as a map and as a value", key)
+		}
+	}
+	return values, nil
+}
+
+// importAttributeValue converts a decoded import ID value into a value of
+// attrType.
+func importAttributeValue(attrType attr.Type, value interface{}) (attr.Value, error) {
+	switch attrType {
+	case types.StringType:
+		s, err := importScalar(value)
+		if err != nil {
+			return nil, err
+		}
+		return types.StringValue(s), nil
+	case types.BoolType:
+		if b, ok := value.(bool); ok {
+			return types.BoolValue(b), nil
+		}
+		s, err := importScalar(value)
+		if err != nil {
+			return nil, err
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("expected a boolean, got %q", s)
+		}
+		return types.BoolValue(b), nil
+	case types.Int64Type:
+		s, err := importScalar(value)
+		if err != nil {
+			return nil, err
+		}
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer, got %q", s)
+		}
+		return types.Int64Value(i), nil
+	case types.ListType{ElemType: types.StringType}:
+		items, ok := value.([]interface{})
+		if !ok {
+			items = []interface{}{value}
+		}
+		elements := make([]attr.Value, 0, len(items))
+		for _, item := range items {
+			s, err := importScalar(item)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, types.StringValue(s))
+		}
+		return types.ListValueMust(types.StringType, elements), nil
+	case types.MapType{ElemType: types.StringType}:
+		items, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a map of strings")
+		}
+		elements := make(map[string]attr.Value, len(items))
+		for k, item := range items {
+			s, err := importScalar(item)
+			if err != nil {
+				return nil, err
+			}
+			elements[k] = types.StringValue(s)
+		}
+		return types.MapValueMust(types.StringType, elements), nil
+	}
+	return nil, fmt.Errorf("the attribute cannot be set on import")
+}
+
+// importScalar returns the string form of a string, number or boolean import
+// value. Numbers are accepted for strings so that e.g. response codes can be
+// written without quotes.
+func importScalar(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("expected a string, number or boolean")
+}
+
+// importStructured imports the resource described by a structured import ID.
+// A configuration matching the import ID plans no changes.
+func (r *CurlResource) importStructured(ctx context.Context, id string, resp *resource.ImportStateResponse) {
+	values, err := parseImportID(id)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	data, diags := importValues(ctx, values, &resp.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(prepareImportedResource(&data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.importRead(ctx, &data, resp)
+}
+
+// importValues sets the attributes given in values and returns the resulting
+// state.
+func importValues(ctx context.Context, values map[string]interface{}, state *tfsdk.State) (CurlResourceModel, diag.Diagnostics) {
+	var data CurlResourceModel
+	var diags diag.Diagnostics
+
+	for name, value := range values {
+		attribute, attrDiags := state.Schema.AttributeAtPath(ctx, path.Root(name))
+		if attrDiags.HasError() || attribute.IsWriteOnly() || (!attribute.IsRequired() && !attribute.IsOptional() && name != "id") {
+			diags.AddError("Invalid Import ID", fmt.Sprintf("%q is not an argument of terracurl_request that can be set on import.", name))
+			continue
+		}
+		attrValue, err := importAttributeValue(attribute.GetType(), value)
+		if err != nil {
+			diags.AddError("Invalid Import ID", fmt.Sprintf("Invalid value for %q: %s", name, err))
+			continue
+		}
+		diags.Append(state.SetAttribute(ctx, path.Root(name), attrValue)...)
+	}
+	if diags.HasError() {
+		return data, diags
+	}
+
+	diags.Append(state.Get(ctx, &data)...)
+	return data, diags
+}
+
+// importRead sends the read request of an imported resource to populate
+// `response` and `status_code`, then saves state and identity.
+func (r *CurlResource) importRead(ctx context.Context, data *CurlResourceModel, resp *resource.ImportStateResponse) {
+	found, diags := r.adoptExisting(ctx, data, resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"Import Error",
+			fmt.Sprintf("The read request to %s did not return one of `read_response_codes`, so there is no object to import.", data.ReadUrl.ValueString()),
+		)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Imported terracurl_request %q", data.Id.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, resp.Identity, data)...)
+}
+
+// prepareImportedResource checks that an imported resource has the arguments
+// the initial read needs and sets the values that are otherwise filled in by
+// defaults or by Create.
+func prepareImportedResource(data *CurlResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.Name.IsNull() {
+		data.Name = data.Id
+	}
+
+	var missing []string
+	for name, value := range map[string]attr.Value{
+		"name":                data.Name,
+		"url":                 data.Url,
+		"method":              data.Method,
+		"response_codes":      data.ResponseCodes,
+		"read_url":            data.ReadUrl,
+		"read_method":         data.ReadMethod,
+		"read_response_codes": data.ReadResponseCodes,
+	} {
+		if value.IsNull() {
+			missing = append(missing, "`"+name+"`")
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		diags.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The import ID must set %s. The read request is sent to look up the object being imported.", strings.Join(missing, ", ")),
+		)
+		return diags
+	}
+
+	setImportDefaults(data)
+	diags.Append(data.setRequestBodyFileHashes()...)
+	if diags.HasError() {
+		return diags
+	}
+
+	requestUrl, err := requestUrlString(data)
+	if err != nil {
+		diags.AddError("Invalid Import ID", fmt.Sprintf("Invalid `url`: %s", err))
+		return diags
+	}
+	data.RequestUrlString = types.StringValue(requestUrl)
+
+	return diags
+}
+
+// setImportDefaults sets the schema defaults, which are not applied on import.
+func setImportDefaults(data *CurlResourceModel) {
+	if data.Id.IsNull() {
+		data.Id = data.Name
+	}
+	if data.RetryInterval.IsNull() {
+		data.RetryInterval = types.Int64Value(10)
+	}
+	if data.Timeout.IsNull() {
+		data.Timeout = types.Int64Value(10)
+	}
+	if data.SkipDestroy.IsNull() {
+		data.SkipDestroy = types.BoolValue(true)
+	}
+	if data.DestroyRetryInterval.IsNull() {
+		data.DestroyRetryInterval = types.Int64Value(10)
+	}
+	if data.DestroyTimeout.IsNull() {
+		data.DestroyTimeout = types.Int64Value(10)
+	}
+	if data.SkipRead.IsNull() {
+		data.SkipRead = types.BoolValue(true)
+	}
+}
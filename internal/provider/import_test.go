@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseImportID(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		expected map[string]interface{}
+	}{
+		{
+			name: "JSON",
+			id:   `{"name": "x", "read_response_codes": [200, "204"], "read_headers": {"Accept": "application/json"}}`,
+			expected: map[string]interface{}{
+				"name":                "x",
+				"read_response_codes": []interface{}{json.Number("200"), "204"},
+				"read_headers":        map[string]interface{}{"Accept": "application/json"},
+			},
+		},
+		{
+			name: "Key value pairs",
+			id:   "name=x,read_url=https://example.com/read?a=b,read_response_codes=200,read_response_codes=204,read_headers.Accept=application/json",
+			expected: map[string]interface{}{
+				"name":                "x",
+				"read_url":            "https://example.com/read?a=b",
+				"read_response_codes": []interface{}{"200", "204"},
+				"read_headers":        map[string]interface{}{"Accept": "application/json"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImportID(tt.id)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %#v, got %#v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseImportIDErrors(t *testing.T) {
+	for _, id := range []string{
+		`{"name": `,
+		"name=x,url",
+		"read_headers.Accept=a,read_headers=b",
+		"read_headers=b,read_headers.Accept=a",
+	} {
+		if _, err := parseImportID(id); err == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+}
+
+func TestImportAttributeValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		attrType attr.Type
+		value    interface{}
+		expected attr.Value
+	}{
+		{name: "String from number", attrType: types.StringType, value: json.Number("5"), expected: types.StringValue("5")},
+		{name: "Bool from string", attrType: types.BoolType, value: "false", expected: types.BoolValue(false)},
+		{name: "Int64 from number", attrType: types.Int64Type, value: json.Number("30"), expected: types.Int64Value(30)},
+		{name: "List from single value", attrType: types.ListType{ElemType: types.StringType}, value: "200", expected: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("200")})},
+		{name: "Map", attrType: types.MapType{ElemType: types.StringType}, value: map[string]interface{}{"a": "b"}, expected: types.MapValueMust(types.StringType, map[string]attr.Value{"a": types.StringValue("b")})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := importAttributeValue(tt.attrType, tt.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := importAttributeValue(types.Int64Type, "ten"); err == nil {
+		t.Errorf("expected an error for a non-numeric integer")
+	}
+	if _, err := importAttributeValue(types.MapType{ElemType: types.StringType}, "a"); err == nil {
+		t.Errorf("expected an error for a map given as a string")
+	}
+}
+
+func TestPrepareImportedResource(t *testing.T) {
+	data := CurlResourceModel{
+		Name:              types.StringValue("x"),
+		Url:               types.StringValue("https://example.com/create"),
+		Method:            types.StringValue("POST"),
+		ResponseCodes:     types.ListValueMust(types.StringType, []attr.Value{types.StringValue("200")}),
+		RequestParameters: types.MapValueMust(types.StringType, map[string]attr.Value{"a": types.StringValue("b")}),
+		ReadUrl:           types.StringValue("https://example.com/read"),
+		ReadMethod:        types.StringValue("GET"),
+		ReadResponseCodes: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("200")}),
+	}
+
+	if diags := prepareImportedResource(&data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if data.Id.ValueString() != "x" {
+		t.Errorf("expected id to default to name, got %s", data.Id)
+	}
+	if data.RequestUrlString.ValueString() != "https://example.com/create?a=b" {
+		t.Errorf("unexpected request_url_string %s", data.RequestUrlString)
+	}
+	if !data.SkipRead.ValueBool() || !data.SkipDestroy.ValueBool() || data.Timeout.ValueInt64() != 10 {
+		t.Errorf("expected schema defaults to be set")
+	}
+
+	missing := CurlResourceModel{Name: types.StringValue("x")}
+	if diags := prepareImportedResource(&missing); !diags.HasError() {
+		t.Errorf("expected an error when required arguments are missing")
+	}
+}
+
+func TestIsStructuredImportID(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	(&CurlResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	state := &tfsdk.State{Schema: schemaResp.Schema}
+
+	for id, expected := range map[string]bool{
+		`{"name": "x"}`:                        true,
+		"name=x,read_response_codes=200":       true,
+		"name=x,read_headers.Accept=text/json": true,
+		"plain-id":                             false,
+		"YWJjZA==":                             false,
+		"abc==":                                false,
+		"name=x,unknown=y":                     false,
+		"a=b,c":                                false,
+	} {
+		if got := isStructuredImportID(ctx, id, state); got != expected {
+			t.Errorf("isStructuredImportID(%q) = %t, expected %t", id, got, expected)
+		}
+	}
+}
+
+// TestImportDefaultsMatchSchema checks that setImportDefaults sets every schema
+// default, and to the same value.
+func TestImportDefaultsMatchSchema(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	(&CurlResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := CurlResourceModel{Name: types.StringValue("x")}
+	setImportDefaults(&data)
+
+	fields := map[string]attr.Value{}
+	value := reflect.ValueOf(data)
+	for i := 0; i < value.NumField(); i++ {
+		if v, ok := value.Field(i).Interface().(attr.Value); ok {
+			fields[value.Type().Field(i).Tag.Get("tfsdk")] = v
+		}
+	}
+
+	defaulted := map[string]bool{}
+	for name, attribute := range schemaResp.Schema.Attributes {
+		var expected attr.Value
+		switch a := attribute.(type) {
+		case schema.Int64Attribute:
+			if a.Default != nil {
+				resp := &defaults.Int64Response{}
+				a.Default.DefaultInt64(ctx, defaults.Int64Request{}, resp)
+				expected = resp.PlanValue
+			}
+		case schema.BoolAttribute:
+			if a.Default != nil {
+				resp := &defaults.BoolResponse{}
+				a.Default.DefaultBool(ctx, defaults.BoolRequest{}, resp)
+				expected = resp.PlanValue
+			}
+		case schema.StringAttribute:
+			if a.Default != nil {
+				resp := &defaults.StringResponse{}
+				a.Default.DefaultString(ctx, defaults.StringRequest{}, resp)
+				expected = resp.PlanValue
+			}
+		}
+		if expected == nil {
+			continue
+		}
+		defaulted[name] = true
+		if got := fields[name]; !expected.Equal(got) {
+			t.Errorf("expected %s to default to %s on import, got %s", name, expected, got)
+		}
+	}
+
+	if len(defaulted) == 0 {
+		t.Fatal("expected the schema to have defaults")
+	}
+	for name, v := range fields {
+		if name != "id" && name != "name" && !v.IsNull() && !defaulted[name] {
+			t.Errorf("setImportDefaults sets %s, which has no schema default", name)
+		}
+	}
+}
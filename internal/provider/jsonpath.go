@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPathSegment is a single step of a JSON path. Exactly one of key or index
+// is meaningful, depending on isIndex.
+type jsonPathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseJSONPath parses paths such as `status`, `data.items[0].id` or
+// `$.data.items[0].id`. A leading `$` is optional and refers to the document
+// root.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$")
+	path = strings.TrimPrefix(path, ".")
+
+	var segments []jsonPathSegment
+	if path == "" {
+		return segments, nil
+	}
+
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			return nil, fmt.Errorf("invalid JSON path %q: empty segment", path)
+		}
+
+		key := part
+		var indexes []string
+		if open := strings.Index(part, "["); open >= 0 {
+			key = part[:open]
+			rest := part[open:]
+			for rest != "" {
+				if !strings.HasPrefix(rest, "[") {
+					return nil, fmt.Errorf("invalid JSON path %q: unexpected %q", path, rest)
+				}
+				end := strings.Index(rest, "]")
+				if end < 0 {
+					return nil, fmt.Errorf("invalid JSON path %q: missing closing bracket", path)
+				}
+				indexes = append(indexes, rest[1:end])
+				rest = rest[end+1:]
+			}
+		}
+
+		if key != "" {
+			segments = append(segments, jsonPathSegment{key: key})
+		}
+
+		for _, raw := range indexes {
+			index, err := strconv.Atoi(raw)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid JSON path %q: %q is not a valid list index", path, raw)
+			}
+			segments = append(segments, jsonPathSegment{index: index, isIndex: true})
+		}
+	}
+
+	return segments, nil
+}
+
+// decodeJSON decodes a response body while keeping numbers in their original
+// textual form, so that `1.0` and large integers are compared as received.
+func decodeJSON(body []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// lookupJSONPath resolves path against a decoded JSON document. The boolean
+// result reports whether the path exists in the document.
+func lookupJSONPath(document interface{}, path string) (interface{}, bool, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	current := document
+	for _, segment := range segments {
+		if segment.isIndex {
+			list, ok := current.([]interface{})
+			if !ok || segment.index >= len(list) {
+				return nil, false, nil
+			}
+			current = list[segment.index]
+			continue
+		}
+
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false, nil
+		}
+		value, exists := object[segment.key]
+		if !exists {
+			return nil, false, nil
+		}
+		current = value
+	}
+
+	return current, true, nil
+}
+
+// jsonValueString renders a value returned by lookupJSONPath as a string.
+// Strings are returned without quotes, while objects and lists are returned
+// as compact JSON.
+func jsonValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(encoded)
+	}
+}
@@ -0,0 +1,52 @@
+package provider
+
+import "testing"
+
+func TestLookupJSONPath(t *testing.T) {
+	document, err := decodeJSON([]byte(`{"status":"ok","count":1.0,"data":{"items":[{"id":"a"},{"id":"b"}],"empty":null}}`))
+	if err != nil {
+		t.Fatalf("unexpected error decoding document: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		expected  string
+		exists    bool
+		expectErr bool
+	}{
+		{name: "Top level key", path: "status", expected: "ok", exists: true},
+		{name: "Leading dollar", path: "$.status", expected: "ok", exists: true},
+		{name: "Number keeps its text", path: "count", expected: "1.0", exists: true},
+		{name: "Nested list index", path: "data.items[1].id", expected: "b", exists: true},
+		{name: "Object rendered as JSON", path: "data.items[0]", expected: `{"id":"a"}`, exists: true},
+		{name: "Null value exists", path: "data.empty", expected: "null", exists: true},
+		{name: "Missing key", path: "data.missing", exists: false},
+		{name: "Index out of range", path: "data.items[5]", exists: false},
+		{name: "Index on an object", path: "data[0]", exists: false},
+		{name: "Empty segment", path: "data..items", expectErr: true},
+		{name: "Invalid index", path: "data.items[x]", expectErr: true},
+		{name: "Missing bracket", path: "data.items[0", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, exists, err := lookupJSONPath(document, tt.path)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error for path %q, got none", tt.path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if exists != tt.exists {
+				t.Errorf("expected exists %v, got %v", tt.exists, exists)
+			}
+			if tt.exists && jsonValueString(value) != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, jsonValueString(value))
+			}
+		})
+	}
+}
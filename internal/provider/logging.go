@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	pschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	maskedValue               = "***"
+	defaultMaxLoggedBodyBytes = 4096
+)
+
+// defaultSensitiveHeaders are always masked in logs.
+var defaultSensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+	"Api-Key",
+	"X-Auth-Token",
+	"X-Vault-Token",
+}
+
+// sensitiveNameParts mark header and query parameter names as sensitive when
+// they contain one of them, e.g. `X-Registry-Token` or `client_secret`.
+var sensitiveNameParts = []string{"token", "secret", "password", "apikey", "api-key", "api_key"}
+
+// LoggingModel describes the `logging` block of the provider.
+type LoggingModel struct {
+	SensitiveHeaders    types.List  `tfsdk:"sensitive_headers"`
+	SensitiveBodyFields types.List  `tfsdk:"sensitive_body_fields"`
+	LogResponseBody     types.Bool  `tfsdk:"log_response_body"`
+	MaxBodyBytes        types.Int64 `tfsdk:"max_body_bytes"`
+}
+
+// loggingOptions controls what the provider writes to the debug log about
+// requests and responses. A nil *loggingOptions uses the defaults.
+type loggingOptions struct {
+	SensitiveHeaders    []string
+	SensitiveBodyFields []string
+	LogResponseBody     bool
+	MaxBodyBytes        int
+}
+
+func loggingProviderBlock() pschema.SingleNestedBlock {
+	return pschema.SingleNestedBlock{
+		MarkdownDescription: "Controls the debug log, e.g. with `TF_LOG=DEBUG`. The values of `Authorization`, cookie, API key and token headers, and of query parameters with similar names, are always masked.",
+		Attributes: map[string]pschema.Attribute{
+			"sensitive_headers": pschema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Additional header names whose values are masked in logs",
+			},
+			"sensitive_body_fields": pschema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "JSON paths of request and response body fields whose values are masked in logs, e.g. `password` or `data.private_key`",
+			},
+			"log_response_body": pschema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Set this to true to log response bodies. Defaults to false",
+			},
+			"max_body_bytes": pschema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum number of bytes of a request or response body written to the log. Defaults to %d", defaultMaxLoggedBodyBytes),
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+		},
+	}
+}
+
+// loggingFromObject converts the provider `logging` block into options.
+func loggingFromObject(ctx context.Context, object types.Object) (*loggingOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	options := &loggingOptions{MaxBodyBytes: defaultMaxLoggedBodyBytes}
+	if object.IsNull() || object.IsUnknown() {
+		return options, diags
+	}
+
+	var model LoggingModel
+	diags.Append(object.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	options.SensitiveHeaders = stringElements(model.SensitiveHeaders)
+	options.SensitiveBodyFields = stringElements(model.SensitiveBodyFields)
+	options.LogResponseBody = model.LogResponseBody.ValueBool()
+	if !model.MaxBodyBytes.IsNull() {
+		options.MaxBodyBytes = int(model.MaxBodyBytes.ValueInt64())
+	}
+	return options, diags
+}
+
+func stringElements(list types.List) []string {
+	var values []string
+	for _, v := range list.Elements() {
+		if strVal, ok := v.(types.String); ok {
+			values = append(values, strVal.ValueString())
+		}
+	}
+	return values
+}
+
+func (o *loggingOptions) orDefault() *loggingOptions {
+	if o == nil {
+		return &loggingOptions{MaxBodyBytes: defaultMaxLoggedBodyBytes}
+	}
+	return o
+}
+
+// isSensitiveName reports whether the values of a header or query parameter
+// must be masked.
+func (o *loggingOptions) isSensitiveName(name string) bool {
+	for _, header := range append(defaultSensitiveHeaders, o.orDefault().SensitiveHeaders...) {
+		if strings.EqualFold(name, header) {
+			return true
+		}
+	}
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveNameParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskedHeaders returns a copy of headers with sensitive values masked.
+func (o *loggingOptions) maskedHeaders(headers http.Header) http.Header {
+	masked := make(http.Header, len(headers))
+	for name, values := range headers {
+		if o.isSensitiveName(name) {
+			masked[name] = []string{maskedValue}
+			continue
+		}
+		masked[name] = values
+	}
+	return masked
+}
+
+// maskedURL returns requestUrl with the values of sensitive query parameters
+// masked.
+func (o *loggingOptions) maskedURL(requestUrl *url.URL) string {
+	query := requestUrl.Query()
+	masked := false
+	for name := range query {
+		if o.isSensitiveName(name) {
+			query[name] = []string{maskedValue}
+			masked = true
+		}
+	}
+	if !masked {
+		return requestUrl.String()
+	}
+	copied := *requestUrl
+	copied.RawQuery = query.Encode()
+	return copied.String()
+}
+
+// maskedBody masks the sensitive fields of a JSON body and truncates it to
+// MaxBodyBytes.
+func (o *loggingOptions) maskedBody(body string) string {
+	options := o.orDefault()
+	if len(options.SensitiveBodyFields) > 0 && body != "" {
+		if document, err := decodeJSON([]byte(body)); err == nil {
+			masked := false
+			for _, path := range options.SensitiveBodyFields {
+				segments, err := parseJSONPath(path)
+				if err != nil || len(segments) == 0 {
+					continue
+				}
+				if _, found, _ := lookupJSONPath(document, path); found {
+					replaceJSONPath(document, segments, maskedValue)
+					masked = true
+				}
+			}
+			if encoded, err := json.Marshal(document); err == nil && masked {
+				body = string(encoded)
+			}
+		}
+	}
+
+	if options.MaxBodyBytes >= 0 && len(body) > options.MaxBodyBytes {
+		return fmt.Sprintf("%s... (%d more bytes)", body[:options.MaxBodyBytes], len(body)-options.MaxBodyBytes)
+	}
+	return body
+}
+
+// secretValues returns the values of sensitive headers, query parameters and
+// body fields of a request.
+func (o *loggingOptions) secretValues(request *http.Request, body string) []string {
+	var secrets []string
+	for name, values := range request.Header {
+		if o.isSensitiveName(name) {
+			secrets = append(secrets, values...)
+		}
+	}
+	for name, values := range request.URL.Query() {
+		if o.isSensitiveName(name) {
+			secrets = append(secrets, values...)
+		}
+	}
+	if isFormRequest(request) {
+		if form, err := url.ParseQuery(body); err == nil {
+			for name, values := range form {
+				if !o.isSensitiveName(name) {
+					continue
+				}
+				// The logged body holds the encoded values.
+				for _, value := range values {
+					secrets = append(secrets, value, url.QueryEscape(value))
+				}
+			}
+		}
+	}
+	if fields := o.orDefault().SensitiveBodyFields; len(fields) > 0 && body != "" {
+		if document, err := decodeJSON([]byte(body)); err == nil {
+			for _, path := range fields {
+				if value, found, err := lookupJSONPath(document, path); err == nil && found {
+					secrets = append(secrets, jsonValueString(value))
+				}
+			}
+		}
+	}
+	return secrets
+}
+
+// withMasking returns ctx with tflog filters masking the secrets of request
+// and the given values in every later log entry, including error messages
+// that echo the request.
+func (o *loggingOptions) withMasking(ctx context.Context, request *http.Request, body string, values ...string) context.Context {
+	var secrets []string
+	for _, secret := range append(o.secretValues(request, body), values...) {
+		// Masking very short values would garble unrelated log output.
+		if len(secret) >= 4 {
+			secrets = append(secrets, secret)
+		}
+	}
+	if len(secrets) == 0 {
+		return ctx
+	}
+	ctx = tflog.MaskMessageStrings(ctx, secrets...)
+	return tflog.MaskAllFieldValuesStrings(ctx, secrets...)
+}
+
+// logRequest writes request to the debug log with secrets masked. Values are
+// logged as strings so that the tflog filters set up by withMasking apply.
+func (o *loggingOptions) logRequest(ctx context.Context, operation string, request *http.Request, body string) {
+	tflog.Debug(ctx, fmt.Sprintf("%s API request", operation), map[string]interface{}{
+		"url":     o.maskedURL(request.URL),
+		"method":  request.Method,
+		"headers": fmt.Sprint(o.maskedHeaders(request.Header)),
+		"body":    o.maskedBody(body),
+	})
+}
+
+// logResponse writes result to the debug log if response body logging is
+// enabled.
+func (o *loggingOptions) logResponse(ctx context.Context, operation string, result *httpResult) {
+	if !o.orDefault().LogResponseBody {
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("%s API response", operation), map[string]interface{}{
+		"status_code": result.StatusCode,
+		"headers":     fmt.Sprint(o.maskedHeaders(result.Header)),
+		"body":        o.maskedBody(string(result.Body)),
+		"latency":     result.Latency.String(),
+	})
+}
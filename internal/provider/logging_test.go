@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingMaskedHeaders(t *testing.T) {
+	options := &loggingOptions{SensitiveHeaders: []string{"X-Custom"}}
+	headers := http.Header{
+		"Authorization":    {"Bearer abc"},
+		"Cookie":           {"session=1"},
+		"X-Custom":         {"custom"},
+		"X-Registry-Token": {"t0k"},
+		"Content-Type":     {"application/json"},
+	}
+
+	masked := options.maskedHeaders(headers)
+	for _, name := range []string{"Authorization", "Cookie", "X-Custom", "X-Registry-Token"} {
+		if got := masked.Get(name); got != maskedValue {
+			t.Errorf("expected %s to be masked, got %q", name, got)
+		}
+	}
+	if got := masked.Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected Content-Type to be logged, got %q", got)
+	}
+	if headers.Get("Authorization") != "Bearer abc" {
+		t.Errorf("expected the request headers to be left unchanged")
+	}
+}
+
+func TestLoggingMaskedURL(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodGet, "https://example.com/path?client_secret=s3cr3t&page=2", nil)
+
+	var options *loggingOptions
+	got := options.maskedURL(request.URL)
+	if strings.Contains(got, "s3cr3t") || !strings.Contains(got, "page=2") {
+		t.Errorf("unexpected masked URL %s", got)
+	}
+	if request.URL.Query().Get("client_secret") != "s3cr3t" {
+		t.Errorf("expected the request URL to be left unchanged")
+	}
+}
+
+func TestLoggingMaskedBody(t *testing.T) {
+	options := &loggingOptions{SensitiveBodyFields: []string{"auth.password"}, MaxBodyBytes: 4096}
+
+	got := options.maskedBody(`{"auth": {"user": "admin", "password": "hunter22"}}`)
+	if got != `{"auth":{"password":"***","user":"admin"}}` {
+		t.Errorf("unexpected masked body %s", got)
+	}
+
+	options.MaxBodyBytes = 5
+	if got := options.maskedBody("0123456789"); got != "01234... (5 more bytes)" {
+		t.Errorf("unexpected truncated body %s", got)
+	}
+}
+
+func TestLoggingWithMasking(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	options := &loggingOptions{SensitiveBodyFields: []string{"password"}, MaxBodyBytes: 4096}
+	request, _ := http.NewRequest(http.MethodPost, "https://example.com/login?token=querytoken", nil)
+	request.Header.Set("Authorization", "Bearer headertoken")
+	request.Header.Set("X-Write-Only", "writeonlyvalue")
+	body := `{"password": "bodypassword"}`
+
+	ctx = options.withMasking(ctx, request, body, "writeonlyvalue")
+	options.logRequest(ctx, "Create", request, body)
+	tflog.Debug(ctx, "request to "+request.URL.String()+" failed with writeonlyvalue")
+
+	for _, secret := range []string{"querytoken", "headertoken", "writeonlyvalue", "bodypassword"} {
+		if strings.Contains(output.String(), secret) {
+			t.Errorf("expected %q to be masked in %s", secret, output.String())
+		}
+	}
+	if !strings.Contains(output.String(), "Create API request") {
+		t.Errorf("expected the request to be logged, got %s", output.String())
+	}
+}
+
+func TestLoggingResponseBody(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	result := &httpResult{StatusCode: 200, Header: http.Header{"Set-Cookie": {"session=abc"}}, Body: []byte(`{"ok": true}`)}
+
+	var disabled *loggingOptions
+	disabled.logResponse(ctx, "Read", result)
+	if output.Len() != 0 {
+		t.Errorf("expected response bodies not to be logged by default, got %s", output.String())
+	}
+
+	(&loggingOptions{LogResponseBody: true, MaxBodyBytes: 4096}).logResponse(ctx, "Read", result)
+	if !strings.Contains(output.String(), `{\"ok\": true}`) || strings.Contains(output.String(), "session=abc") {
+		t.Errorf("unexpected response log %s", output.String())
+	}
+}
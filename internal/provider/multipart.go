@@ -0,0 +1,450 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	eschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const defaultMultipartFileContentType = "application/octet-stream"
+
+// MultipartModel describes a `*multipart` block.
+type MultipartModel struct {
+	Field types.List `tfsdk:"field"`
+	File  types.List `tfsdk:"file"`
+}
+
+// MultipartFieldModel describes a `field` block of a `*multipart` block.
+type MultipartFieldModel struct {
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+}
+
+// MultipartFileModel describes a `file` block of a `*multipart` block.
+type MultipartFileModel struct {
+	Name        types.String `tfsdk:"name"`
+	Path        types.String `tfsdk:"path"`
+	Content     types.String `tfsdk:"content"`
+	Filename    types.String `tfsdk:"filename"`
+	ContentType types.String `tfsdk:"content_type"`
+}
+
+var multipartFieldObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":  types.StringType,
+		"value": types.StringType,
+	},
+}
+
+var multipartFileObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":         types.StringType,
+		"path":         types.StringType,
+		"content":      types.StringType,
+		"filename":     types.StringType,
+		"content_type": types.StringType,
+	},
+}
+
+// multipartAttrTypes are the attribute types of a `*multipart` block object.
+var multipartAttrTypes = map[string]attr.Type{
+	"field": types.ListType{ElemType: multipartFieldObjectType},
+	"file":  types.ListType{ElemType: multipartFileObjectType},
+}
+
+const multipartBlockDescription = "Sends the %s request body as `multipart/form-data`. The boundary and `Content-Type` header are generated, and files are streamed from disk on every attempt instead of being loaded into memory. Conflicts with `%s`."
+
+const (
+	multipartFieldDescription           = "A text field."
+	multipartFieldNameDescription       = "Name of the field."
+	multipartFieldValueDescription      = "Value of the field."
+	multipartFileDescription            = "A file part. Exactly one of `path` and `content` must be set."
+	multipartFileNameDescription        = "Name of the form field the file is sent as."
+	multipartFilePathDescription        = "Path to a file on local disk to send."
+	multipartFileContentDescription     = "Inline content to send as the file."
+	multipartFileFilenameDescription    = "Filename sent with the part. Defaults to the base name of `path`."
+	multipartFileContentTypeDescription = "Content type of the part. Defaults to `" + defaultMultipartFileContentType + "`."
+)
+
+func multipartFileSourceValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("content")),
+	}
+}
+
+func multipartResourceBlock(operation string, conflictsWith string, planModifiers ...planmodifier.Object) rschema.SingleNestedBlock {
+	return rschema.SingleNestedBlock{
+		MarkdownDescription: fmt.Sprintf(multipartBlockDescription, operation, conflictsWith),
+		PlanModifiers:       planModifiers,
+		Blocks: map[string]rschema.Block{
+			"field": rschema.ListNestedBlock{
+				MarkdownDescription: multipartFieldDescription,
+				NestedObject: rschema.NestedBlockObject{
+					Attributes: map[string]rschema.Attribute{
+						"name":  rschema.StringAttribute{Required: true, MarkdownDescription: multipartFieldNameDescription},
+						"value": rschema.StringAttribute{Required: true, MarkdownDescription: multipartFieldValueDescription},
+					},
+				},
+			},
+			"file": rschema.ListNestedBlock{
+				MarkdownDescription: multipartFileDescription,
+				NestedObject: rschema.NestedBlockObject{
+					Attributes: map[string]rschema.Attribute{
+						"name":         rschema.StringAttribute{Required: true, MarkdownDescription: multipartFileNameDescription},
+						"path":         rschema.StringAttribute{Optional: true, MarkdownDescription: multipartFilePathDescription, Validators: multipartFileSourceValidators()},
+						"content":      rschema.StringAttribute{Optional: true, MarkdownDescription: multipartFileContentDescription},
+						"filename":     rschema.StringAttribute{Optional: true, MarkdownDescription: multipartFileFilenameDescription},
+						"content_type": rschema.StringAttribute{Optional: true, MarkdownDescription: multipartFileContentTypeDescription},
+					},
+				},
+			},
+		},
+	}
+}
+
+func multipartDataSourceBlock() dschema.SingleNestedBlock {
+	return dschema.SingleNestedBlock{
+		MarkdownDescription: fmt.Sprintf(multipartBlockDescription, "data source", "request_body"),
+		Blocks: map[string]dschema.Block{
+			"field": dschema.ListNestedBlock{
+				MarkdownDescription: multipartFieldDescription,
+				NestedObject: dschema.NestedBlockObject{
+					Attributes: map[string]dschema.Attribute{
+						"name":  dschema.StringAttribute{Required: true, MarkdownDescription: multipartFieldNameDescription},
+						"value": dschema.StringAttribute{Required: true, MarkdownDescription: multipartFieldValueDescription},
+					},
+				},
+			},
+			"file": dschema.ListNestedBlock{
+				MarkdownDescription: multipartFileDescription,
+				NestedObject: dschema.NestedBlockObject{
+					Attributes: map[string]dschema.Attribute{
+						"name":         dschema.StringAttribute{Required: true, MarkdownDescription: multipartFileNameDescription},
+						"path":         dschema.StringAttribute{Optional: true, MarkdownDescription: multipartFilePathDescription, Validators: multipartFileSourceValidators()},
+						"content":      dschema.StringAttribute{Optional: true, MarkdownDescription: multipartFileContentDescription},
+						"filename":     dschema.StringAttribute{Optional: true, MarkdownDescription: multipartFileFilenameDescription},
+						"content_type": dschema.StringAttribute{Optional: true, MarkdownDescription: multipartFileContentTypeDescription},
+					},
+				},
+			},
+		},
+	}
+}
+
+func multipartEphemeralBlock() eschema.SingleNestedBlock {
+	return eschema.SingleNestedBlock{
+		MarkdownDescription: fmt.Sprintf(multipartBlockDescription, "open", "request_body"),
+		Blocks: map[string]eschema.Block{
+			"field": eschema.ListNestedBlock{
+				MarkdownDescription: multipartFieldDescription,
+				NestedObject: eschema.NestedBlockObject{
+					Attributes: map[string]eschema.Attribute{
+						"name":  eschema.StringAttribute{Required: true, MarkdownDescription: multipartFieldNameDescription},
+						"value": eschema.StringAttribute{Required: true, MarkdownDescription: multipartFieldValueDescription},
+					},
+				},
+			},
+			"file": eschema.ListNestedBlock{
+				MarkdownDescription: multipartFileDescription,
+				NestedObject: eschema.NestedBlockObject{
+					Attributes: map[string]eschema.Attribute{
+						"name":         eschema.StringAttribute{Required: true, MarkdownDescription: multipartFileNameDescription},
+						"path":         eschema.StringAttribute{Optional: true, MarkdownDescription: multipartFilePathDescription, Validators: multipartFileSourceValidators()},
+						"content":      eschema.StringAttribute{Optional: true, MarkdownDescription: multipartFileContentDescription},
+						"filename":     eschema.StringAttribute{Optional: true, MarkdownDescription: multipartFileFilenameDescription},
+						"content_type": eschema.StringAttribute{Optional: true, MarkdownDescription: multipartFileContentTypeDescription},
+					},
+				},
+			},
+		},
+	}
+}
+
+// multipartBody is the evaluated form of a MultipartModel. It is also stored
+// in ephemeral private data, so it must remain JSON serialisable.
+type multipartBody struct {
+	Fields []multipartField `json:"fields,omitempty"`
+	Files  []multipartFile  `json:"files,omitempty"`
+}
+
+type multipartField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// multipartFile is a file part. Its content is read from Path, or is Content
+// when Path is empty.
+type multipartFile struct {
+	Name        string `json:"name"`
+	Path        string `json:"path,omitempty"`
+	Content     string `json:"content,omitempty"`
+	Filename    string `json:"filename,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+}
+
+// multipartFromObject converts a `*multipart` block. It returns nil if the
+// block is not set.
+func multipartFromObject(ctx context.Context, object types.Object) (*multipartBody, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if object.IsNull() || object.IsUnknown() {
+		return nil, diags
+	}
+
+	var model MultipartModel
+	diags.Append(object.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	var fields []MultipartFieldModel
+	if !model.Field.IsNull() && !model.Field.IsUnknown() {
+		diags.Append(model.Field.ElementsAs(ctx, &fields, false)...)
+	}
+	var files []MultipartFileModel
+	if !model.File.IsNull() && !model.File.IsUnknown() {
+		diags.Append(model.File.ElementsAs(ctx, &files, false)...)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	body := &multipartBody{}
+	for _, field := range fields {
+		body.Fields = append(body.Fields, multipartField{
+			Name:  field.Name.ValueString(),
+			Value: field.Value.ValueString(),
+		})
+	}
+	for _, file := range files {
+		part := multipartFile{
+			Name:        file.Name.ValueString(),
+			Path:        file.Path.ValueString(),
+			Content:     file.Content.ValueString(),
+			Filename:    file.Filename.ValueString(),
+			ContentType: file.ContentType.ValueString(),
+		}
+		if part.Filename == "" && part.Path != "" {
+			part.Filename = filepath.Base(part.Path)
+		}
+		if part.ContentType == "" {
+			part.ContentType = defaultMultipartFileContentType
+		}
+		body.Files = append(body.Files, part)
+	}
+	return body, diags
+}
+
+// setMultipartBody converts a `*multipart` block and, if it is set, makes it
+// the body of request.
+func setMultipartBody(ctx context.Context, object types.Object, request *http.Request) (*multipartBody, diag.Diagnostics) {
+	parts, diags := multipartFromObject(ctx, object)
+	if parts == nil || diags.HasError() {
+		return nil, diags
+	}
+	if err := parts.setBody(request); err != nil {
+		diags.AddError("Multipart Body Error", err.Error())
+		return nil, diags
+	}
+	return parts, diags
+}
+
+// setBody makes the multipart body the body of request and sets its
+// `Content-Type` header. Files are checked now and read again on every
+// attempt, so that retries send the same content without holding it in
+// memory.
+func (m *multipartBody) setBody(request *http.Request) error {
+	writer := multipart.NewWriter(io.Discard)
+	boundary := writer.Boundary()
+
+	length, err := m.contentLength(boundary)
+	if err != nil {
+		return err
+	}
+
+	request.GetBody = func() (io.ReadCloser, error) {
+		return &multipartReader{body: m, boundary: boundary}, nil
+	}
+	request.Body, _ = request.GetBody()
+	request.ContentLength = length
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return nil
+}
+
+// contentLength returns the size of the encoded body without reading the
+// files.
+func (m *multipartBody) contentLength(boundary string) (int64, error) {
+	counter := &countingWriter{}
+	fileBytes, err := m.write(counter, boundary, false)
+	if err != nil {
+		return 0, err
+	}
+	return counter.n + fileBytes, nil
+}
+
+// write encodes the body to w. Unless copyFiles is set, files on disk are only
+// checked and their total size is returned instead of being written.
+func (m *multipartBody) write(w io.Writer, boundary string, copyFiles bool) (int64, error) {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return 0, err
+	}
+
+	for _, field := range m.Fields {
+		if err := writer.WriteField(field.Name, field.Value); err != nil {
+			return 0, err
+		}
+	}
+
+	var skipped int64
+	for _, file := range m.Files {
+		part, err := writer.CreatePart(file.header())
+		if err != nil {
+			return 0, err
+		}
+		n, err := file.writeContent(part, copyFiles)
+		if err != nil {
+			return 0, fmt.Errorf("multipart file %q: %w", file.Name, err)
+		}
+		skipped += n
+	}
+	return skipped, writer.Close()
+}
+
+// describe summarises the body for the debug log without field values or
+// file contents.
+func (m *multipartBody) describe() string {
+	var parts []string
+	for _, field := range m.Fields {
+		parts = append(parts, fmt.Sprintf("field %q", field.Name))
+	}
+	for _, file := range m.Files {
+		source := "inline content"
+		if file.Path != "" {
+			source = file.Path
+		}
+		parts = append(parts, fmt.Sprintf("file %q (%s)", file.Name, source))
+	}
+	return fmt.Sprintf("multipart/form-data: %s", strings.Join(parts, ", "))
+}
+
+// digest returns a SHA-256 of the fields and of the file parts, including the
+// content of files on disk, so that idempotency keys change with the body
+// without holding it.
+func (m *multipartBody) digest() (string, error) {
+	encoded, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write(encoded)
+	for _, file := range m.Files {
+		if file.Path == "" {
+			continue
+		}
+		sum, err := fileSHA256(file.Path)
+		if err != nil {
+			return "", fmt.Errorf("multipart file %q: %w", file.Name, err)
+		}
+		hash.Write([]byte(sum))
+	}
+	return "multipart:sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func (f multipartFile) header() textproto.MIMEHeader {
+	disposition := fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(f.Name))
+	if f.Filename != "" {
+		disposition += fmt.Sprintf(`; filename="%s"`, quoteEscaper.Replace(f.Filename))
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", disposition)
+	header.Set("Content-Type", f.ContentType)
+	return header
+}
+
+// writeContent writes the content of the part to w. Unless copyFile is set, a
+// file on disk is only checked and its size is returned instead.
+func (f multipartFile) writeContent(w io.Writer, copyFile bool) (int64, error) {
+	if f.Path == "" {
+		_, err := io.WriteString(w, f.Content)
+		return 0, err
+	}
+
+	if !copyFile {
+		info, err := os.Stat(f.Path)
+		if err != nil {
+			return 0, err
+		}
+		if !info.Mode().IsRegular() {
+			return 0, fmt.Errorf("%s is not a regular file", f.Path)
+		}
+		return info.Size(), nil
+	}
+
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	_, err = io.Copy(w, file)
+	return 0, err
+}
+
+// multipartReader streams an encoded multipart body. Encoding only starts on
+// the first read, so a body that is never sent does not leave a goroutine
+// behind.
+type multipartReader struct {
+	body     *multipartBody
+	boundary string
+	pipe     *io.PipeReader
+}
+
+func (r *multipartReader) Read(p []byte) (int, error) {
+	if r.pipe == nil {
+		pipeReader, pipeWriter := io.Pipe()
+		go func() {
+			_, err := r.body.write(pipeWriter, r.boundary, true)
+			_ = pipeWriter.CloseWithError(err)
+		}()
+		r.pipe = pipeReader
+	}
+	return r.pipe.Read(p)
+}
+
+func (r *multipartReader) Close() error {
+	if r.pipe == nil {
+		return nil
+	}
+	return r.pipe.Close()
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
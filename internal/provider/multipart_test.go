@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMultipartBody(t *testing.T) {
+	uploadPath := filepath.Join(t.TempDir(), "artifact.bin")
+	content := strings.Repeat("0123456789", 100000)
+	if err := os.WriteFile(uploadPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.ContentLength <= 0 || len(r.TransferEncoding) > 0 {
+			t.Errorf("expected a Content-Length, got %d %v", r.ContentLength, r.TransferEncoding)
+		}
+		if err := r.ParseMultipartForm(1 << 10); err != nil {
+			t.Errorf("attempt %d: failed to parse the body: %s", attempts, err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if got := r.FormValue("description"); got != `say "hi"` {
+			t.Errorf("unexpected field %q", got)
+		}
+
+		file, header, err := r.FormFile("artifact")
+		if err != nil {
+			t.Errorf("missing file part: %s", err)
+			return
+		}
+		defer file.Close()
+		received, _ := io.ReadAll(file)
+		if string(received) != content || header.Filename != "artifact.bin" || header.Header.Get("Content-Type") != defaultMultipartFileContentType {
+			t.Errorf("unexpected file part %q (%d bytes)", header.Filename, len(received))
+		}
+
+		_, inline, err := r.FormFile("metadata")
+		if err != nil || inline.Filename != "metadata.json" || inline.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected inline part %v: %v", inline, err)
+		}
+
+		// Fail the first attempt so that the body is sent twice.
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	parts := &multipartBody{
+		Fields: []multipartField{{Name: "description", Value: `say "hi"`}},
+		Files: []multipartFile{
+			{Name: "artifact", Path: uploadPath, Filename: filepath.Base(uploadPath), ContentType: defaultMultipartFileContentType},
+			{Name: "metadata", Content: `{"version": "1.0.0"}`, Filename: "metadata.json", ContentType: "application/json"},
+		},
+	}
+
+	request, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+	if err := parts.setBody(request); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.HasPrefix(request.Header.Get("Content-Type"), "multipart/form-data; boundary=") {
+		t.Errorf("unexpected Content-Type %q", request.Header.Get("Content-Type"))
+	}
+
+	_, err := executeRequest(context.Background(), server.Client(), request, requestOptions{
+		Operation:     "Create",
+		MaxRetry:      1,
+		RetryInterval: time.Millisecond,
+		ResponseCodes: []string{"200"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+
+	describe := parts.describe()
+	if strings.Contains(describe, "hi") || !strings.Contains(describe, uploadPath) {
+		t.Errorf("unexpected description %q", describe)
+	}
+}
+
+func TestMultipartBodyMissingFile(t *testing.T) {
+	parts := &multipartBody{
+		Files: []multipartFile{{Name: "artifact", Path: filepath.Join(t.TempDir(), "missing.zip")}},
+	}
+	request, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	if err := parts.setBody(request); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestMultipartBodyDigest(t *testing.T) {
+	uploadPath := filepath.Join(t.TempDir(), "artifact.bin")
+	if err := os.WriteFile(uploadPath, []byte("v1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	parts := &multipartBody{
+		Fields: []multipartField{{Name: "description", Value: "first"}},
+		Files:  []multipartFile{{Name: "artifact", Path: uploadPath}},
+	}
+	digest, err := parts.digest()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if again, _ := parts.digest(); again != digest {
+		t.Errorf("expected the same digest for the same body, got %q and %q", digest, again)
+	}
+
+	if err := os.WriteFile(uploadPath, []byte("v2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	changedFile, err := parts.digest()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if changedFile == digest {
+		t.Error("expected a different digest when the file content changes")
+	}
+
+	parts.Fields[0].Value = "second"
+	if changedField, _ := parts.digest(); changedField == changedFile {
+		t.Error("expected a different digest when a field value changes")
+	}
+
+	parts.Files[0].Path = filepath.Join(t.TempDir(), "missing.bin")
+	if _, err := parts.digest(); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const resourceVersionPrivateKey = "resource_version"
+
+// resourceVersion holds the validators of the remote object as last seen by
+// Terraform.
+type resourceVersion struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+func resourceVersionFromResult(result *httpResult) resourceVersion {
+	return resourceVersion{
+		ETag:         result.Header.Get("ETag"),
+		LastModified: result.Header.Get("Last-Modified"),
+	}
+}
+
+func (v resourceVersion) isEmpty() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// saveResourceVersion stores the validators of result in private state. A
+// response without validators removes the stored version, as it may no longer
+// describe the remote object.
+func saveResourceVersion(ctx context.Context, private privateStateWriter, result *httpResult) diag.Diagnostics {
+	version := resourceVersionFromResult(result)
+	if version.isEmpty() {
+		return private.SetKey(ctx, resourceVersionPrivateKey, nil)
+	}
+	return setPrivateJSON(ctx, private, resourceVersionPrivateKey, version)
+}
+
+// loadResourceVersion returns the validators stored in private state.
+func loadResourceVersion(ctx context.Context, private privateStateReader) (resourceVersion, diag.Diagnostics) {
+	var version resourceVersion
+	_, diags := getPrivateJSON(ctx, private, resourceVersionPrivateKey, &version)
+	return version, diags
+}
+
+// setPreconditionHeaders makes request conditional on the remote object still
+// being the version stored in private state. Headers that are already set are
+// left untouched.
+func setPreconditionHeaders(ctx context.Context, private privateStateReader, request *http.Request) diag.Diagnostics {
+	version, diags := loadResourceVersion(ctx, private)
+	if diags.HasError() {
+		return diags
+	}
+
+	if version.isEmpty() {
+		tflog.Warn(ctx, fmt.Sprintf("optimistic_locking is enabled but no ETag or Last-Modified header has been received for %s, sending an unconditional request", request.URL.String()))
+		return diags
+	}
+
+	if version.ETag != "" && request.Header.Get("If-Match") == "" {
+		request.Header.Set("If-Match", version.ETag)
+	}
+	if version.LastModified != "" && request.Header.Get("If-Unmodified-Since") == "" {
+		request.Header.Set("If-Unmodified-Since", version.LastModified)
+	}
+	return diags
+}
+
+// addConflictError reports a request rejected with 412 Precondition Failed.
+func addConflictError(diags *diag.Diagnostics, operation string, result *httpResult) {
+	diags.AddError(
+		"Conflict Detected",
+		fmt.Sprintf("The %s request was rejected with 412 Precondition Failed because the remote object has changed since Terraform last read it. "+
+			"Refresh the state, e.g. with `terraform apply -refresh-only`, review the changes and try again. Response: %s", operation, string(result.Body)),
+	)
+}
+
+// preconditionResponseCodes returns the response codes that must not be
+// retried when optimistic locking is enabled.
+func preconditionResponseCodes(optimisticLocking bool) []string {
+	if !optimisticLocking {
+		return nil
+	}
+	return []string{"412"}
+}
+
+// preconditionFailed reports whether err is a 412 Precondition Failed response.
+func preconditionFailed(err error) (*httpResult, bool) {
+	var statusErr *unexpectedStatusError
+	if errors.As(err, &statusErr) && statusErr.Result.StatusCode == http.StatusPreconditionFailed {
+		return statusErr.Result, true
+	}
+	return nil, false
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// testPrivateState is an in-memory private state for unit tests.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(p, key)
+		return nil
+	}
+	p[key] = value
+	return nil
+}
+
+func TestResourceVersionRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateState{}
+
+	result := &httpResult{Header: http.Header{}}
+	result.Header.Set("ETag", `"v1"`)
+	result.Header.Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+	if diags := saveResourceVersion(ctx, private, result); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	request, _ := http.NewRequest(http.MethodPut, "https://example.com", nil)
+	if diags := setPreconditionHeaders(ctx, private, request); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got := request.Header.Get("If-Match"); got != `"v1"` {
+		t.Errorf("unexpected If-Match %q", got)
+	}
+	if got := request.Header.Get("If-Unmodified-Since"); got != "Wed, 21 Oct 2015 07:28:00 GMT" {
+		t.Errorf("unexpected If-Unmodified-Since %q", got)
+	}
+
+	// A response without validators forgets the stored version.
+	if diags := saveResourceVersion(ctx, private, &httpResult{Header: http.Header{}}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	request, _ = http.NewRequest(http.MethodPut, "https://example.com", nil)
+	if diags := setPreconditionHeaders(ctx, private, request); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got := request.Header.Get("If-Match"); got != "" {
+		t.Errorf("expected no If-Match header, got %q", got)
+	}
+}
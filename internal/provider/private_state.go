@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// privateStateReader is implemented by the Private field of resource requests.
+type privateStateReader interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateWriter is implemented by the Private field of resource
+// responses.
+type privateStateWriter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// getPrivateJSON decodes the private state value stored under key into
+// target. It reports whether the key was present.
+func getPrivateJSON(ctx context.Context, private privateStateReader, key string, target interface{}) (bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, key)
+	if diags.HasError() || len(value) == 0 {
+		return false, diags
+	}
+
+	if err := json.Unmarshal(value, target); err != nil {
+		diags.AddError("Private State Error", fmt.Sprintf("Failed to decode private state key %q: %s", key, err))
+		return false, diags
+	}
+	return true, diags
+}
+
+// setPrivateJSON stores value under key as JSON.
+func setPrivateJSON(ctx context.Context, private privateStateWriter, key string, value interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		diags.AddError("Private State Error", fmt.Sprintf("Failed to encode private state key %q: %s", key, err))
+		return diags
+	}
+	return private.SetKey(ctx, key, encoded)
+}
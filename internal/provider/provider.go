@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure TerraCurlProvider satisfies various provider interfaces.
@@ -28,6 +29,20 @@ type TerraCurlProvider struct {
 
 // TerraCurlProviderModel describes the provider data model.
 type TerraCurlProviderModel struct {
+	RetryPolicy types.Object `tfsdk:"retry_policy"`
+	Logging     types.Object `tfsdk:"logging"`
+}
+
+// providerData is passed to resources, data sources and ephemeral resources
+// when they are configured.
+type providerData struct {
+	Client *http.Client
+	// RetryPolicy is the provider level default retry policy, or nil if the
+	// `retry_policy` block is not set.
+	RetryPolicy *retryPolicy
+	// Logging controls what requests and responses are written to the debug
+	// log.
+	Logging *loggingOptions
 }
 
 func (p *TerraCurlProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -38,6 +53,10 @@ func (p *TerraCurlProvider) Metadata(ctx context.Context, req provider.MetadataR
 func (p *TerraCurlProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "The TerraCurl provider allows you to make custom HTTP requests in Terraform.",
+		Blocks: map[string]schema.Block{
+			"retry_policy": retryPolicyProviderBlock(),
+			"logging":      loggingProviderBlock(),
+		},
 	}
 }
 
@@ -50,12 +69,26 @@ func (p *TerraCurlProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	// Configuration values are now available.
+	policy, diags := retryPolicyFromObject(ctx, data.RetryPolicy, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	logging, diags := loggingFromObject(ctx, data.Logging)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Example client configuration for data sources and resources.
-	client := http.DefaultClient
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	providerData := &providerData{
+		Client:      http.DefaultClient,
+		RetryPolicy: policy,
+		Logging:     logging,
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
 }
 
 func (p *TerraCurlProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const sensitiveResponseFieldsDescription = "JSON paths of response fields holding secrets, e.g. `api_key` or `data.credentials[0].password`. Their values are replaced with a `sha256:` hash in `response` and are only available in `sensitive_response`. The hash lets drift detection notice a changed value without storing it"
+
+// redactedValuePrefix marks a response field replaced by the hash of its value.
+const redactedValuePrefix = "sha256:"
+
+// redactResponse replaces the values at paths in a JSON response body with a
+// hash of the value and returns the original values keyed by path. The same
+// value always produces the same hash, so drift detection on the redacted
+// body still notices a changed secret. Paths missing from the body are
+// skipped, and a body that is not JSON is returned unchanged.
+func redactResponse(body string, paths []string) (string, map[string]string, error) {
+	values := map[string]string{}
+	if len(paths) == 0 || body == "" {
+		return body, values, nil
+	}
+
+	document, err := decodeJSON([]byte(body))
+	if err != nil {
+		return body, values, nil
+	}
+
+	for _, path := range paths {
+		segments, err := parseJSONPath(path)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(segments) == 0 {
+			return "", nil, fmt.Errorf("invalid JSON path %q: the whole response cannot be redacted", path)
+		}
+
+		value, found, err := lookupJSONPath(document, path)
+		if err != nil {
+			return "", nil, err
+		}
+		if !found {
+			continue
+		}
+
+		plain := jsonValueString(value)
+		if isRedactedValue(plain) {
+			continue
+		}
+		values[path] = plain
+		replaceJSONPath(document, segments, redactedValue(plain))
+	}
+
+	redacted, err := json.Marshal(document)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to serialize redacted JSON: %v", err)
+	}
+	return string(redacted), values, nil
+}
+
+// redactedValue returns the value stored in place of a redacted field.
+func redactedValue(plain string) string {
+	return fmt.Sprintf("%s%x", redactedValuePrefix, sha256.Sum256([]byte(plain)))
+}
+
+// isRedactedValue reports whether value was produced by redactedValue, so that
+// a stored response can be redacted again without hashing its hashes.
+func isRedactedValue(value string) bool {
+	if !strings.HasPrefix(value, redactedValuePrefix) || len(value) != len(redactedValuePrefix)+2*sha256.Size {
+		return false
+	}
+	_, err := hex.DecodeString(strings.TrimPrefix(value, redactedValuePrefix))
+	return err == nil
+}
+
+// replaceJSONPath sets the existing value at segments to value.
+func replaceJSONPath(document interface{}, segments []jsonPathSegment, value interface{}) {
+	current := document
+	for i, segment := range segments {
+		last := i == len(segments)-1
+
+		if segment.isIndex {
+			list := current.([]interface{})
+			if last {
+				list[segment.index] = value
+				return
+			}
+			current = list[segment.index]
+			continue
+		}
+
+		object := current.(map[string]interface{})
+		if last {
+			object[segment.key] = value
+			return
+		}
+		current = object[segment.key]
+	}
+}
+
+// setResponse stores body in `response` with the `sensitive_response_fields`
+// redacted, and their values in `sensitive_response`.
+func (data *CurlResourceModel) setResponse(ctx context.Context, body []byte) diag.Diagnostics {
+	bodyString := string(body)
+	if bodyString == "" {
+		bodyString = "{}"
+	}
+
+	redacted, sensitive, diags := redactResponseFields(ctx, bodyString, data.SensitiveResponseFields)
+	data.Response = types.StringValue(redacted)
+	data.SensitiveResponse = sensitive
+	return diags
+}
+
+// setWaitForResponse stores body in `wait_for_response` with the
+// `sensitive_response_fields` redacted.
+func (data *CurlResourceModel) setWaitForResponse(ctx context.Context, body []byte) diag.Diagnostics {
+	redacted, _, diags := redactResponseFields(ctx, string(body), data.SensitiveResponseFields)
+	data.WaitForResponse = types.StringValue(redacted)
+	return diags
+}
+
+// redactStoredResponse copies the responses in state to data, redacting any
+// `sensitive_response_fields` added since they were received. Values redacted
+// earlier are kept in `sensitive_response` while their path is still listed.
+func (data *CurlResourceModel) redactStoredResponse(ctx context.Context, state *CurlResourceModel) diag.Diagnostics {
+	data.Response = state.Response
+	data.SensitiveResponse = state.SensitiveResponse
+	data.WaitForResponse = state.WaitForResponse
+
+	if !state.Response.IsNull() && !state.Response.IsUnknown() {
+		redacted, sensitive, diags := redactResponseFields(ctx, state.Response.ValueString(), data.SensitiveResponseFields)
+		if diags.HasError() {
+			return diags
+		}
+		if !sensitive.IsNull() {
+			elements := sensitive.Elements()
+			for path, value := range state.SensitiveResponse.Elements() {
+				if _, ok := elements[path]; !ok && hasSensitiveResponseField(data.SensitiveResponseFields, path) {
+					elements[path] = value
+				}
+			}
+			var mapDiags diag.Diagnostics
+			sensitive, mapDiags = types.MapValue(types.StringType, elements)
+			diags.Append(mapDiags...)
+		}
+		data.Response = types.StringValue(redacted)
+		data.SensitiveResponse = sensitive
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if !state.WaitForResponse.IsNull() && !state.WaitForResponse.IsUnknown() {
+		redacted, _, diags := redactResponseFields(ctx, state.WaitForResponse.ValueString(), data.SensitiveResponseFields)
+		if diags.HasError() {
+			return diags
+		}
+		data.WaitForResponse = types.StringValue(redacted)
+	}
+	return nil
+}
+
+// hasSensitiveResponseField reports whether path is listed in fields.
+func hasSensitiveResponseField(fields types.List, path string) bool {
+	for _, v := range fields.Elements() {
+		if strVal, ok := v.(types.String); ok && strVal.ValueString() == path {
+			return true
+		}
+	}
+	return false
+}
+
+// redactResponseFields applies a `sensitive_response_fields` list to body and
+// returns the redacted body and the `sensitive_response` map. The map is null
+// when no fields are configured.
+func redactResponseFields(ctx context.Context, body string, fields types.List) (string, types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if fields.IsNull() || fields.IsUnknown() {
+		return body, types.MapNull(types.StringType), diags
+	}
+
+	var paths []string
+	for _, v := range fields.Elements() {
+		if strVal, ok := v.(types.String); ok {
+			paths = append(paths, strVal.ValueString())
+		}
+	}
+
+	redacted, values, err := redactResponse(body, paths)
+	if err != nil {
+		diags.AddError("Redaction Error", fmt.Sprintf("Failed to redact `sensitive_response_fields`: %s", err))
+		return "", types.MapNull(types.StringType), diags
+	}
+	if len(values) < len(paths) {
+		tflog.Debug(ctx, fmt.Sprintf("%d of %d sensitive_response_fields were not found in the response", len(paths)-len(values), len(paths)))
+	}
+
+	elements := make(map[string]attr.Value, len(values))
+	for path, value := range values {
+		elements[path] = types.StringValue(value)
+	}
+	sensitive, mapDiags := types.MapValue(types.StringType, elements)
+	diags.Append(mapDiags...)
+	return redacted, sensitive, diags
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRedactResponse(t *testing.T) {
+	body := `{"id": 1, "credentials": [{"password": "p4ss"}], "token": {"value": "t0k"}}`
+
+	redacted, values, err := redactResponse(body, []string{"credentials[0].password", "token", "missing"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"credentials":[{"password":"` + redactedValue("p4ss") + `"}],"id":1,"token":"` + redactedValue(`{"value":"t0k"}`) + `"}`
+	if redacted != expected {
+		t.Errorf("expected %s, got %s", expected, redacted)
+	}
+	if values["credentials[0].password"] != "p4ss" || values["token"] != `{"value":"t0k"}` || len(values) != 2 {
+		t.Errorf("unexpected values %v", values)
+	}
+
+	again, values, err := redactResponse(redacted, []string{"credentials[0].password", "token"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if again != redacted || len(values) != 0 {
+		t.Errorf("expected redacting twice to leave the response unchanged, got %s", again)
+	}
+}
+
+func TestRedactResponseNotJSON(t *testing.T) {
+	redacted, values, err := redactResponse("plain text", []string{"secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if redacted != "plain text" || len(values) != 0 {
+		t.Errorf("expected a non-JSON body to be returned unchanged, got %s", redacted)
+	}
+
+	if _, _, err := redactResponse(`{"a": 1}`, []string{"$"}); err == nil {
+		t.Errorf("expected an error when redacting the whole response")
+	}
+}
+
+func TestRedactResponseFieldsDetectsChangedSecret(t *testing.T) {
+	ctx := context.Background()
+	fields := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("secret")})
+
+	first, sensitive, diags := redactResponseFields(ctx, `{"secret": "a"}`, fields)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if sensitive.Elements()["secret"] != types.StringValue("a") {
+		t.Errorf("unexpected sensitive_response %s", sensitive)
+	}
+
+	second, _, _ := redactResponseFields(ctx, `{"secret": "b"}`, fields)
+	if first == second {
+		t.Errorf("expected a changed secret to change the redacted response")
+	}
+
+	_, sensitive, _ = redactResponseFields(ctx, `{"secret": "a"}`, types.ListNull(types.StringType))
+	if !sensitive.IsNull() {
+		t.Errorf("expected a null sensitive_response without sensitive_response_fields")
+	}
+}
+
+func TestRedactStoredResponse(t *testing.T) {
+	ctx := context.Background()
+	state := CurlResourceModel{
+		Response:          types.StringValue(`{"old":"` + redactedValue("o") + `","token":"t"}`),
+		SensitiveResponse: types.MapValueMust(types.StringType, map[string]attr.Value{"old": types.StringValue("o")}),
+		WaitForResponse:   types.StringValue(`{"token":"t"}`),
+	}
+	data := CurlResourceModel{
+		SensitiveResponseFields: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("old"), types.StringValue("token")}),
+	}
+
+	diags := data.redactStoredResponse(ctx, &state)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	expected := `{"old":"` + redactedValue("o") + `","token":"` + redactedValue("t") + `"}`
+	if data.Response.ValueString() != expected {
+		t.Errorf("expected response %s, got %s", expected, data.Response.ValueString())
+	}
+	if data.WaitForResponse.ValueString() != `{"token":"`+redactedValue("t")+`"}` {
+		t.Errorf("expected wait_for_response to be redacted, got %s", data.WaitForResponse.ValueString())
+	}
+	elements := data.SensitiveResponse.Elements()
+	if elements["old"] != types.StringValue("o") || elements["token"] != types.StringValue("t") || len(elements) != 2 {
+		t.Errorf("unexpected sensitive_response %s", data.SensitiveResponse)
+	}
+
+	again := data
+	diags = again.redactStoredResponse(ctx, &data)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !again.Response.Equal(data.Response) || !again.SensitiveResponse.Equal(data.SensitiveResponse) {
+		t.Errorf("expected redacting the stored response twice to leave it unchanged")
+	}
+}
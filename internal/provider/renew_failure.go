@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Values of `on_renew_failure`.
+const (
+	renewFailureError  = "error"
+	renewFailureReopen = "reopen"
+	renewFailureIgnore = "ignore"
+)
+
+var _ validator.String = renewFailureIgnoreValidator{}
+
+// renewFailureIgnoreValidator checks that `renew_interval` is positive when
+// `on_renew_failure` is `ignore`. A failed renew is retried after it, so
+// without it Renew would be called again immediately.
+type renewFailureIgnoreValidator struct{}
+
+func (v renewFailureIgnoreValidator) Description(_ context.Context) string {
+	return "`renew_interval` must be set to a positive number of seconds when the value is `ignore`"
+}
+
+func (v renewFailureIgnoreValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v renewFailureIgnoreValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.ValueString() != renewFailureIgnore {
+		return
+	}
+
+	var renewInterval types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("renew_interval"), &renewInterval)...)
+	if resp.Diagnostics.HasError() || renewInterval.IsUnknown() {
+		return
+	}
+	if renewInterval.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Renew Failure Mode",
+			"`on_renew_failure` is set to `ignore`, which sends the renew request again after `renew_interval`, so `renew_interval` must be set to a positive number of seconds.",
+		)
+	}
+}
+
+// handleRenewFailure applies `on_renew_failure` once the renew request failed
+// with err. An interrupted renew always fails the run.
+func (e *EphemeralCurlResource) handleRenewFailure(ctx context.Context, privateData *ephemeralPrivateData, err error, resp *ephemeral.RenewResponse) {
+	var interruptErr *interruptedError
+	if errors.As(err, &interruptErr) {
+		addRequestError(&resp.Diagnostics, err)
+		return
+	}
+
+	switch privateData.OnRenewFailure {
+	case renewFailureIgnore:
+		resp.RenewAt = time.Now().Add(time.Duration(privateData.RenewInterval) * time.Second)
+		resp.Diagnostics.AddWarning(
+			"Renew Failed",
+			fmt.Sprintf("The renew request failed: %s. `on_renew_failure` is set to `ignore`, so the renew request is sent again after `renew_interval` (%d seconds).", err, privateData.RenewInterval),
+		)
+	case renewFailureReopen:
+		e.reopen(ctx, privateData, err, resp)
+	default:
+		addRequestError(&resp.Diagnostics, err)
+	}
+}
+
+// reopen sends the open request again after the renew request failed with
+// renewErr. Renewals continue from the new open response.
+func (e *EphemeralCurlResource) reopen(ctx context.Context, privateData *ephemeralPrivateData, renewErr error, resp *ephemeral.RenewResponse) {
+	open := privateData.Open
+	if open == nil {
+		addRequestError(&resp.Diagnostics, renewErr)
+		return
+	}
+
+	client, request, err := open.newRequest()
+	if err != nil {
+		resp.Diagnostics.AddError("Reopen Error", err.Error())
+		return
+	}
+
+	ctx = e.logging.withMasking(ctx, request, open.Secrets.loggedBody())
+	e.logging.logRequest(ctx, "Open", request, open.Secrets.loggedBody())
+
+	result, err := executeRequest(ctx, client, request, open.options("Open", e.logging))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Reopen Failed",
+			fmt.Sprintf("The renew request failed: %s. The open request sent again to recover also failed: %s", renewErr, err),
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Open request sent again after a failed renew completed with status code %d", result.StatusCode))
+
+	if privateData.Responses != nil {
+		// Later renew and close requests use the values of the new open
+		// response.
+		openResponse := newEphemeralResponse(request, result)
+		privateData.Responses = &ephemeralResponses{Open: openResponse, Latest: openResponse}
+		resp.Diagnostics.Append(privateData.save(ctx, resp.Private)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	renewAt, diags := nextRenewAt(privateData.RenewSchedule, privateData.RenewInterval, result.Body, time.Now())
+	resp.Diagnostics.Append(diags...)
+	resp.RenewAt = renewAt
+	resp.Diagnostics.AddWarning(
+		"Ephemeral Resource Reopened",
+		fmt.Sprintf("The renew request failed: %s. `on_renew_failure` is set to `reopen`, so the open request was sent again and returned status code %d. Values already read from the ephemeral resource, such as `response`, are not updated.", renewErr, result.StatusCode),
+	)
+}
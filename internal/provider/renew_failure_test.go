@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestHandleRenewFailure(t *testing.T) {
+	opens := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		opens++
+		if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"expires_in": 100}`))
+	}))
+	defer server.Close()
+
+	renewErr := &unexpectedStatusError{Result: &httpResult{StatusCode: 404}}
+	openRequest := func(token string) *ephemeralRequest {
+		return &ephemeralRequest{
+			Url:           server.URL,
+			Method:        "POST",
+			ResponseCodes: []string{"200"},
+			Secrets:       ephemeralRequestSecrets{Headers: map[string]string{"Authorization": "Bearer " + token}},
+		}
+	}
+
+	testCases := map[string]struct {
+		privateData *ephemeralPrivateData
+		err         error
+		opens       int
+		renewAt     time.Duration
+		warning     bool
+		error       bool
+	}{
+		"error": {
+			privateData: &ephemeralPrivateData{RenewInterval: 30},
+			err:         renewErr,
+			error:       true,
+		},
+		"ignore": {
+			privateData: &ephemeralPrivateData{RenewInterval: 30, OnRenewFailure: renewFailureIgnore},
+			err:         renewErr,
+			renewAt:     30 * time.Second,
+			warning:     true,
+		},
+		"reopen": {
+			privateData: &ephemeralPrivateData{
+				RenewInterval:  30,
+				RenewSchedule:  &renewSchedule{Source: renewAtFromTtl, Path: "expires_in", SafetyMarginPercent: 10},
+				OnRenewFailure: renewFailureReopen,
+				Open:           openRequest("s3cr3t"),
+			},
+			err:     renewErr,
+			opens:   1,
+			renewAt: 90 * time.Second,
+			warning: true,
+		},
+		"reopen fails": {
+			privateData: &ephemeralPrivateData{RenewInterval: 30, OnRenewFailure: renewFailureReopen, Open: openRequest("revoked")},
+			err:         renewErr,
+			opens:       1,
+			error:       true,
+		},
+		"interrupted": {
+			privateData: &ephemeralPrivateData{RenewInterval: 30, OnRenewFailure: renewFailureReopen, Open: openRequest("s3cr3t")},
+			err:         &interruptedError{Operation: "Renew", Cause: context.DeadlineExceeded},
+			error:       true,
+		},
+	}
+
+	e := &EphemeralCurlResource{}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			opens = 0
+			resp := &ephemeral.RenewResponse{}
+			start := time.Now()
+			e.handleRenewFailure(context.Background(), tc.privateData, tc.err, resp)
+
+			if resp.Diagnostics.HasError() != tc.error {
+				t.Fatalf("expected error %t, got %v", tc.error, resp.Diagnostics)
+			}
+			if warned := resp.Diagnostics.WarningsCount() > 0; warned != tc.warning {
+				t.Errorf("expected warning %t, got %v", tc.warning, resp.Diagnostics)
+			}
+			if opens != tc.opens {
+				t.Errorf("expected %d open requests, got %d", tc.opens, opens)
+			}
+			if tc.renewAt == 0 {
+				if !resp.RenewAt.IsZero() {
+					t.Errorf("expected no renewal, got %s", resp.RenewAt)
+				}
+				return
+			}
+			if delay := resp.RenewAt.Sub(start); delay < tc.renewAt || delay > tc.renewAt+5*time.Second {
+				t.Errorf("expected a renewal in %s, got %s", tc.renewAt, delay)
+			}
+		})
+	}
+}
+
+func TestRenewFailureIgnoreValidator(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &ephemeral.SchemaResponse{}
+	(&EphemeralCurlResource{}).Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	config := func(renewInterval tftypes.Value) tfsdk.Config {
+		values := map[string]tftypes.Value{}
+		for name, attrType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		values["renew_interval"] = renewInterval
+		return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	}
+
+	testCases := map[string]struct {
+		mode          string
+		renewInterval tftypes.Value
+		error         bool
+	}{
+		"ignore with interval":    {mode: renewFailureIgnore, renewInterval: tftypes.NewValue(tftypes.Number, 30)},
+		"ignore without interval": {mode: renewFailureIgnore, renewInterval: tftypes.NewValue(tftypes.Number, nil), error: true},
+		"ignore with zero":        {mode: renewFailureIgnore, renewInterval: tftypes.NewValue(tftypes.Number, 0), error: true},
+		"ignore with unknown":     {mode: renewFailureIgnore, renewInterval: tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)},
+		"reopen without interval": {mode: renewFailureReopen, renewInterval: tftypes.NewValue(tftypes.Number, nil)},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			renewFailureIgnoreValidator{}.ValidateString(ctx, validator.StringRequest{
+				Path:        path.Root("on_renew_failure"),
+				ConfigValue: types.StringValue(tc.mode),
+				Config:      config(tc.renewInterval),
+			}, resp)
+			if resp.Diagnostics.HasError() != tc.error {
+				t.Errorf("expected error %t, got %v", tc.error, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	eschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const defaultRenewSafetyMarginPercent = 10
+
+// minRenewDelay is the shortest time nextRenewAt waits before renewing, so
+// that an unset `renew_interval` or an already expired response does not
+// renew in a loop.
+const minRenewDelay = 5 * time.Second
+
+// Sources of a renew schedule.
+const (
+	renewAtFromTtl       = "ttl"
+	renewAtFromExpiresAt = "expires_at"
+	renewAtFromJwt       = "jwt"
+)
+
+// RenewAtFromModel describes the ephemeral `renew_at_from` block.
+type RenewAtFromModel struct {
+	TtlPath             types.String `tfsdk:"ttl_path"`
+	ExpiresAtPath       types.String `tfsdk:"expires_at_path"`
+	JwtPath             types.String `tfsdk:"jwt_path"`
+	SafetyMarginPercent types.Int64  `tfsdk:"safety_margin_percent"`
+}
+
+func renewAtFromEphemeralBlock() eschema.SingleNestedBlock {
+	return eschema.SingleNestedBlock{
+		MarkdownDescription: "Schedules renewals from the expiry returned by the open and renew requests instead of the fixed `renew_interval`. Exactly one of `ttl_path`, `expires_at_path` and `jwt_path` must be set. When the expiry is missing from a response, `renew_interval` is used. Renewals are never scheduled less than 5 seconds ahead.",
+		Attributes: map[string]eschema.Attribute{
+			"ttl_path": eschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "JSON path of the remaining lifetime in seconds, e.g. `expires_in` or `auth.lease_duration`",
+				Validators:          []validator.String{jsonPathValidator{}},
+			},
+			"expires_at_path": eschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "JSON path of the expiry time as an RFC 3339 timestamp or Unix time in seconds, e.g. `expiration`",
+				Validators:          []validator.String{jsonPathValidator{}},
+			},
+			"jwt_path": eschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "JSON path of a JWT whose `exp` claim is the expiry time, e.g. `access_token`. Use `$` when the whole response body is the JWT",
+				Validators:          []validator.String{jsonPathValidator{}},
+			},
+			"safety_margin_percent": eschema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Percentage of the remaining lifetime left when renewing, e.g. with 10 a token valid for an hour is renewed after 54 minutes. Defaults to %d", defaultRenewSafetyMarginPercent),
+				Validators:          []validator.Int64{int64validator.Between(0, 99)},
+			},
+		},
+	}
+}
+
+// renewSchedule is the evaluated `renew_at_from` block. It is stored in
+// ephemeral private data so that Renew can apply it to renew responses.
+type renewSchedule struct {
+	Source              string `json:"source"`
+	Path                string `json:"path"`
+	SafetyMarginPercent int64  `json:"safety_margin_percent"`
+}
+
+// renewScheduleFromObject converts the `renew_at_from` block. It returns nil
+// if the block is not set.
+func renewScheduleFromObject(ctx context.Context, object types.Object) (*renewSchedule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if object.IsNull() || object.IsUnknown() {
+		return nil, diags
+	}
+
+	var model RenewAtFromModel
+	diags.Append(object.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	schedule := &renewSchedule{SafetyMarginPercent: defaultRenewSafetyMarginPercent}
+	if !model.SafetyMarginPercent.IsNull() {
+		schedule.SafetyMarginPercent = model.SafetyMarginPercent.ValueInt64()
+	}
+
+	sources := 0
+	for source, value := range map[string]types.String{
+		renewAtFromTtl:       model.TtlPath,
+		renewAtFromExpiresAt: model.ExpiresAtPath,
+		renewAtFromJwt:       model.JwtPath,
+	} {
+		if value.IsNull() {
+			continue
+		}
+		sources++
+		schedule.Source = source
+		schedule.Path = value.ValueString()
+		if _, err := parseJSONPath(schedule.Path); err != nil {
+			diags.AddAttributeError(path.Root("renew_at_from"), "Invalid Renew Schedule", err.Error())
+		}
+	}
+	if sources != 1 {
+		diags.AddAttributeError(
+			path.Root("renew_at_from"),
+			"Invalid Renew Schedule",
+			"Exactly one of `ttl_path`, `expires_at_path` and `jwt_path` must be set.",
+		)
+	}
+	return schedule, diags
+}
+
+// expiresAt returns the expiry time found in a response body received at
+// now.
+func (s *renewSchedule) expiresAt(body []byte, now time.Time) (time.Time, error) {
+	var value interface{}
+	document, err := decodeJSON(body)
+	switch {
+	case err == nil:
+		var found bool
+		value, found, err = lookupJSONPath(document, s.Path)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !found || value == nil {
+			return time.Time{}, fmt.Errorf("%q was not found in the response", s.Path)
+		}
+	case s.Source == renewAtFromJwt && isRootJSONPath(s.Path):
+		// A raw token body is not JSON.
+		value = strings.TrimSpace(string(body))
+	default:
+		return time.Time{}, errors.New("the response is not JSON")
+	}
+
+	switch s.Source {
+	case renewAtFromTtl:
+		ttl, err := parseTtl(jsonValueString(value))
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(ttl), nil
+	case renewAtFromExpiresAt:
+		return parseExpiryTime(jsonValueString(value))
+	case renewAtFromJwt:
+		return jwtExpiry(jsonValueString(value))
+	}
+	return time.Time{}, fmt.Errorf("unknown renew schedule source %q", s.Source)
+}
+
+// nextRenewAt returns when to renew after receiving body at now. Without a
+// schedule, or when the response holds no expiry, the resource is renewed
+// renewInterval seconds from now. It never renews sooner than minRenewDelay
+// from now.
+func nextRenewAt(schedule *renewSchedule, renewInterval int64, body []byte, now time.Time) (time.Time, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	delay := renewDelay(time.Duration(renewInterval) * time.Second)
+	fallback := now.Add(delay)
+	if schedule == nil {
+		return fallback, diags
+	}
+
+	expiresAt, err := schedule.expiresAt(body, now)
+	if err != nil {
+		diags.AddWarning(
+			"Renew Schedule Not Found",
+			fmt.Sprintf("Could not read the expiry from the response: %s. The ephemeral resource is renewed after `renew_interval` (%d seconds) instead.", err, renewInterval),
+		)
+		return fallback, diags
+	}
+
+	lifetime := expiresAt.Sub(now)
+	return now.Add(renewDelay(lifetime * time.Duration(100-schedule.SafetyMarginPercent) / 100)), diags
+}
+
+// renewDelay raises delay to minRenewDelay.
+func renewDelay(delay time.Duration) time.Duration {
+	if delay < minRenewDelay {
+		return minRenewDelay
+	}
+	return delay
+}
+
+// isRootJSONPath reports whether path refers to the whole document.
+func isRootJSONPath(path string) bool {
+	segments, err := parseJSONPath(path)
+	return err == nil && len(segments) == 0
+}
+
+// parseTtl parses a lifetime given in seconds, or as a duration such as `1h`.
+func parseTtl(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	if ttl, err := time.ParseDuration(value); err == nil {
+		return ttl, nil
+	}
+	return 0, fmt.Errorf("%q is not a number of seconds", value)
+}
+
+// parseExpiryTime parses an RFC 3339 timestamp or a Unix time in seconds.
+func parseExpiryTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Unix(0, int64(seconds*float64(time.Second))), nil
+	}
+	return time.Time{}, fmt.Errorf("%q is neither an RFC 3339 timestamp nor a Unix time", value)
+}
+
+// jwtExpiry returns the `exp` claim of a JWT. The signature is not verified,
+// the token is only inspected to schedule its renewal.
+func jwtExpiry(token string) (time.Time, error) {
+	token = strings.TrimSpace(strings.TrimPrefix(token, "Bearer "))
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("the value is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid JWT payload: %s", err)
+	}
+
+	var claims struct {
+		Exp *json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("invalid JWT claims: %s", err)
+	}
+	if claims.Exp == nil {
+		return time.Time{}, errors.New("the JWT has no `exp` claim")
+	}
+	return parseExpiryTime(claims.Exp.String())
+}
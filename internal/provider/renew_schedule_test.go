@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testJwt(claims string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"none"}`)) + "." + encode([]byte(claims)) + ".signature"
+}
+
+func TestNextRenewAt(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		schedule *renewSchedule
+		body     string
+		expected time.Time
+		warning  bool
+	}{
+		"no schedule": {
+			body:     `{"expires_in": 3600}`,
+			expected: now.Add(30 * time.Second),
+		},
+		"ttl": {
+			schedule: &renewSchedule{Source: renewAtFromTtl, Path: "expires_in", SafetyMarginPercent: 10},
+			body:     `{"expires_in": 3600}`,
+			expected: now.Add(54 * time.Minute),
+		},
+		"ttl string": {
+			schedule: &renewSchedule{Source: renewAtFromTtl, Path: "auth.lease_duration", SafetyMarginPercent: 0},
+			body:     `{"auth": {"lease_duration": "1h"}}`,
+			expected: now.Add(time.Hour),
+		},
+		"expires at timestamp": {
+			schedule: &renewSchedule{Source: renewAtFromExpiresAt, Path: "expiration", SafetyMarginPercent: 50},
+			body:     `{"expiration": "2025-01-01T14:00:00Z"}`,
+			expected: now.Add(time.Hour),
+		},
+		"expires at unix time": {
+			schedule: &renewSchedule{Source: renewAtFromExpiresAt, Path: "expiration", SafetyMarginPercent: 50},
+			body:     `{"expiration": 1735740000}`,
+			expected: now.Add(time.Hour),
+		},
+		"jwt field": {
+			schedule: &renewSchedule{Source: renewAtFromJwt, Path: "access_token", SafetyMarginPercent: 25},
+			body:     `{"access_token": "` + testJwt(`{"sub":"x","exp":1735740000}`) + `"}`,
+			expected: now.Add(90 * time.Minute),
+		},
+		"jwt body": {
+			schedule: &renewSchedule{Source: renewAtFromJwt, Path: "$", SafetyMarginPercent: 0},
+			body:     testJwt(`{"exp":1735740000}`),
+			expected: now.Add(2 * time.Hour),
+		},
+		"already expired": {
+			schedule: &renewSchedule{Source: renewAtFromTtl, Path: "expires_in", SafetyMarginPercent: 10},
+			body:     `{"expires_in": 0}`,
+			expected: now.Add(minRenewDelay),
+		},
+		"about to expire": {
+			schedule: &renewSchedule{Source: renewAtFromTtl, Path: "expires_in", SafetyMarginPercent: 10},
+			body:     `{"expires_in": 1}`,
+			expected: now.Add(minRenewDelay),
+		},
+		"missing": {
+			schedule: &renewSchedule{Source: renewAtFromTtl, Path: "expires_in", SafetyMarginPercent: 10},
+			body:     `{}`,
+			expected: now.Add(30 * time.Second),
+			warning:  true,
+		},
+		"jwt without exp": {
+			schedule: &renewSchedule{Source: renewAtFromJwt, Path: "token", SafetyMarginPercent: 10},
+			body:     `{"token": "` + testJwt(`{"sub":"x"}`) + `"}`,
+			expected: now.Add(30 * time.Second),
+			warning:  true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			renewAt, diags := nextRenewAt(tc.schedule, 30, []byte(tc.body), now)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if warned := diags.WarningsCount() > 0; warned != tc.warning {
+				t.Errorf("expected warning %t, got %v", tc.warning, diags)
+			}
+			if !renewAt.Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, renewAt)
+			}
+		})
+	}
+}
+
+func TestNextRenewAtWithoutInterval(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	schedule := &renewSchedule{Source: renewAtFromTtl, Path: "expires_in", SafetyMarginPercent: 10}
+
+	for name, s := range map[string]*renewSchedule{"no schedule": nil, "missing expiry": schedule} {
+		renewAt, _ := nextRenewAt(s, 0, []byte(`{}`), now)
+		if !renewAt.Equal(now.Add(minRenewDelay)) {
+			t.Errorf("%s: expected a renewal after %s, got %s", name, minRenewDelay, renewAt.Sub(now))
+		}
+	}
+}
+
+func TestRenewScheduleFromObject(t *testing.T) {
+	ctx := context.Background()
+	attrTypes := map[string]attr.Type{
+		"ttl_path":              types.StringType,
+		"expires_at_path":       types.StringType,
+		"jwt_path":              types.StringType,
+		"safety_margin_percent": types.Int64Type,
+	}
+
+	schedule, diags := renewScheduleFromObject(ctx, types.ObjectNull(attrTypes))
+	if diags.HasError() || schedule != nil {
+		t.Fatalf("expected no schedule, got %v %v", schedule, diags)
+	}
+
+	schedule, diags = renewScheduleFromObject(ctx, types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		"ttl_path":              types.StringValue("expires_in"),
+		"expires_at_path":       types.StringNull(),
+		"jwt_path":              types.StringNull(),
+		"safety_margin_percent": types.Int64Null(),
+	}))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if *schedule != (renewSchedule{Source: renewAtFromTtl, Path: "expires_in", SafetyMarginPercent: defaultRenewSafetyMarginPercent}) {
+		t.Errorf("unexpected schedule %+v", schedule)
+	}
+
+	_, diags = renewScheduleFromObject(ctx, types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		"ttl_path":              types.StringValue("expires_in"),
+		"expires_at_path":       types.StringValue("expiration"),
+		"jwt_path":              types.StringNull(),
+		"safety_margin_percent": types.Int64Null(),
+	}))
+	if !diags.HasError() {
+		t.Error("expected an error when more than one source is set")
+	}
+}
@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	requestBodyFileDescription       = "Path to a local file sent as the %s request body. The file is streamed on every attempt instead of being held in memory or state; only its SHA-256 is stored, in `%s`. Conflicts with %s."
+	requestBodyFileSHA256Description = "SHA-256 of `%s`. A change of the file content plans %s."
+)
+
+// bodyFile is a request body read from a local file.
+type bodyFile struct {
+	Path   string
+	Size   int64
+	SHA256 string
+}
+
+// openBodyFile checks that path is a regular file and hashes its content.
+func openBodyFile(path string) (*bodyFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+	hash, err := fileSHA256(path)
+	if err != nil {
+		return nil, err
+	}
+	return &bodyFile{Path: path, Size: info.Size(), SHA256: hash}, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// setBody makes the file the body of request. The file is opened again on
+// every attempt, so that retries resend it. Unless chunked is set, the
+// `Content-Length` header is set to the size of the file.
+func (f *bodyFile) setBody(request *http.Request, chunked bool) {
+	request.GetBody = func() (io.ReadCloser, error) {
+		return &bodyFileReader{path: f.Path}, nil
+	}
+	request.Body, _ = request.GetBody()
+	request.ContentLength = f.Size
+	if chunked {
+		// An unknown length makes the client use chunked transfer encoding.
+		request.ContentLength = -1
+	}
+}
+
+// setRequestBodyFile makes the file at path, if it is set, the body of
+// request.
+func setRequestBodyFile(request *http.Request, path types.String, chunked types.Bool) (*bodyFile, error) {
+	if path.IsNull() || path.IsUnknown() {
+		return nil, nil
+	}
+	file, err := openBodyFile(path.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	file.setBody(request, chunked.ValueBool())
+	return file, nil
+}
+
+// setPlannedRequestBodyFile sets the body file of a create or update request
+// and records its SHA-256 in hash. It fails if the file changed after the
+// plan was made, since the plan would no longer describe the request.
+func setPlannedRequestBodyFile(request *http.Request, path types.String, hash *types.String, chunked types.Bool) (*bodyFile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	file, err := setRequestBodyFile(request, path, chunked)
+	if err != nil {
+		diags.AddError("Request Body File Error", err.Error())
+		return nil, diags
+	}
+	if file == nil {
+		return nil, diags
+	}
+	if !hash.IsUnknown() && !hash.IsNull() && hash.ValueString() != file.SHA256 {
+		diags.AddError(
+			"Request Body File Changed",
+			fmt.Sprintf("The content of %s changed after the plan was made. Its SHA-256 is %s instead of %s. Run the plan again to send the new content.", file.Path, file.SHA256, hash.ValueString()),
+		)
+		return nil, diags
+	}
+	*hash = types.StringValue(file.SHA256)
+	return file, diags
+}
+
+// hashOrBody returns the SHA-256 of the request body file if one is set, and
+// body otherwise. Idempotency keys use it so that they change with the file
+// content.
+func hashOrBody(hash types.String, body string) string {
+	if !hash.IsNull() && !hash.IsUnknown() {
+		return "sha256:" + hash.ValueString()
+	}
+	return body
+}
+
+// loggedFileOrBody names the request body file for the debug log, the way
+// curl refers to a body read from a file, if one is set. It returns body
+// otherwise.
+func loggedFileOrBody(path types.String, body string) string {
+	if !path.IsNull() && !path.IsUnknown() {
+		return "@" + path.ValueString()
+	}
+	return body
+}
+
+// setRequestBodyFileHashes sets the SHA-256 of the request body files whose
+// hash is not known yet, because they did not exist when the plan was made or
+// the resource was imported.
+func (data *CurlResourceModel) setRequestBodyFileHashes() diag.Diagnostics {
+	var diags diag.Diagnostics
+	for attribute, file := range map[string]struct {
+		path types.String
+		hash *types.String
+	}{
+		"request_body_file":         {data.RequestBodyFile, &data.RequestBodyFileSha256},
+		"read_request_body_file":    {data.ReadRequestBodyFile, &data.ReadRequestBodyFileSha256},
+		"update_request_body_file":  {data.UpdateRequestBodyFile, &data.UpdateRequestBodyFileSha256},
+		"destroy_request_body_file": {data.DestroyRequestBodyFile, &data.DestroyRequestBodyFileSha256},
+	} {
+		if file.path.IsNull() {
+			*file.hash = types.StringNull()
+			continue
+		}
+		if !file.hash.IsNull() && !file.hash.IsUnknown() {
+			continue
+		}
+		hash, err := fileSHA256(file.path.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(attribute), "Request Body File Error", err.Error())
+			continue
+		}
+		*file.hash = types.StringValue(hash)
+	}
+	return diags
+}
+
+// requestBodyFileHash plans the SHA-256 of the file named by the attribute at
+// file. A file that does not exist yet, e.g. because another resource writes
+// it during apply, is hashed when the request is sent.
+func requestBodyFileHash(file path.Path) planmodifier.String {
+	return requestBodyFileHashModifier{file: file}
+}
+
+type requestBodyFileHashModifier struct {
+	file path.Path
+}
+
+func (m requestBodyFileHashModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("Set to the SHA-256 of the content of `%s`.", m.file)
+}
+
+func (m requestBodyFileHashModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m requestBodyFileHashModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var file types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, m.file, &file)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case file.IsNull():
+		resp.PlanValue = types.StringNull()
+		return
+	case file.IsUnknown():
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	hash, err := fileSHA256(file.ValueString())
+	if errors.Is(err, fs.ErrNotExist) {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(m.file, "Request Body File Error", err.Error())
+		return
+	}
+	resp.PlanValue = types.StringValue(hash)
+}
+
+// bodyFileConflicts returns the pairs of attributes of an operation, e.g.
+// "update_", that cannot be set together with its request body file.
+func bodyFileConflicts(prefix string, bodies ...string) [][]path.Expression {
+	var pairs [][]path.Expression
+	for _, body := range bodies {
+		pairs = append(pairs, []path.Expression{path.MatchRoot(prefix + "request_body_file"), path.MatchRoot(prefix + body)})
+	}
+	return pairs
+}
+
+// bodyFileReader reads a request body file. The file is only opened on the
+// first read, so a body that is never sent does not hold a file descriptor.
+type bodyFileReader struct {
+	path string
+	file *os.File
+}
+
+func (r *bodyFileReader) Read(p []byte) (int, error) {
+	if r.file == nil {
+		file, err := os.Open(r.path)
+		if err != nil {
+			return 0, err
+		}
+		r.file = file
+	}
+	return r.file.Read(p)
+}
+
+func (r *bodyFileReader) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRequestBodyFile(t *testing.T) {
+	bodyPath := filepath.Join(t.TempDir(), "dashboard.json")
+	content := strings.Repeat(`{"panel": "cpu"}`, 50000)
+	if err := os.WriteFile(bodyPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte(content))
+
+	for name, chunked := range map[string]bool{"content length": false, "chunked": true} {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if chunked && (len(r.TransferEncoding) == 0 || r.TransferEncoding[0] != "chunked") {
+					t.Errorf("expected chunked transfer encoding, got %v", r.TransferEncoding)
+				}
+				if !chunked && r.ContentLength != int64(len(content)) {
+					t.Errorf("expected a Content-Length of %d, got %d", len(content), r.ContentLength)
+				}
+				received, _ := io.ReadAll(r.Body)
+				if string(received) != content {
+					t.Errorf("attempt %d: unexpected body of %d bytes", attempts, len(received))
+				}
+
+				// Fail the first attempt so that the file is sent twice.
+				if attempts == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			defer server.Close()
+
+			request, _ := http.NewRequest(http.MethodPut, server.URL, nil)
+			file, err := setRequestBodyFile(request, types.StringValue(bodyPath), types.BoolValue(chunked))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if file.SHA256 != hex.EncodeToString(sum[:]) {
+				t.Errorf("unexpected SHA-256 %s", file.SHA256)
+			}
+
+			_, err = executeRequest(context.Background(), server.Client(), request, requestOptions{
+				Operation:     "Update",
+				MaxRetry:      1,
+				RetryInterval: time.Millisecond,
+				ResponseCodes: []string{"200"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if attempts != 2 {
+				t.Errorf("expected 2 attempts, got %d", attempts)
+			}
+		})
+	}
+}
+
+func TestSetPlannedRequestBodyFile(t *testing.T) {
+	bodyPath := filepath.Join(t.TempDir(), "cert.pem")
+	if err := os.WriteFile(bodyPath, []byte("certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte("certificate"))
+
+	request, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	hash := types.StringUnknown()
+	if _, diags := setPlannedRequestBodyFile(request, types.StringValue(bodyPath), &hash, types.BoolNull()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if hash.ValueString() != hex.EncodeToString(sum[:]) {
+		t.Errorf("expected the hash to be recorded, got %s", hash)
+	}
+
+	stale := types.StringValue("0000")
+	if _, diags := setPlannedRequestBodyFile(request, types.StringValue(bodyPath), &stale, types.BoolNull()); !diags.HasError() {
+		t.Error("expected an error for a file changed after the plan")
+	}
+
+	hash = types.StringNull()
+	if _, diags := setPlannedRequestBodyFile(request, types.StringValue(filepath.Join(t.TempDir(), "missing.pem")), &hash, types.BoolNull()); !diags.HasError() {
+		t.Error("expected an error for a missing file")
+	}
+
+	if got := hashOrBody(types.StringValue("abc"), "body"); got != "sha256:abc" {
+		t.Errorf("unexpected idempotency body %q", got)
+	}
+	if got := hashOrBody(types.StringNull(), "body"); got != "body" {
+		t.Errorf("unexpected idempotency body %q", got)
+	}
+}
@@ -0,0 +1,361 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	eschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	pschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	defaultRetryMaxInterval = 60 * time.Second
+	defaultRetryMultiplier  = 2.0
+)
+
+// rateLimitResetHeaders are checked, in order, when a 429 or 503 response has
+// no `Retry-After` header. Their value is either a number of seconds or a
+// Unix timestamp.
+var rateLimitResetHeaders = []string{
+	"RateLimit-Reset",
+	"X-RateLimit-Reset",
+	"X-Rate-Limit-Reset",
+}
+
+// RetryPolicyModel describes a `*retry_policy` block.
+type RetryPolicyModel struct {
+	InitialInterval   types.Int64   `tfsdk:"initial_interval"`
+	MaxInterval       types.Int64   `tfsdk:"max_interval"`
+	Multiplier        types.Float64 `tfsdk:"multiplier"`
+	Jitter            types.Bool    `tfsdk:"jitter"`
+	MaxElapsedTime    types.Int64   `tfsdk:"max_elapsed_time"`
+	RespectRetryAfter types.Bool    `tfsdk:"respect_retry_after"`
+
+	RetryOnStatusCodes      types.List   `tfsdk:"retry_on_status_codes"`
+	RetryOnConnectionErrors types.Bool   `tfsdk:"retry_on_connection_errors"`
+	RetryOnTimeouts         types.Bool   `tfsdk:"retry_on_timeouts"`
+	RetryOnBodyRegex        types.String `tfsdk:"retry_on_body_regex"`
+}
+
+// retryPolicyAttrTypes are the attribute types of a `*retry_policy` block
+// object.
+var retryPolicyAttrTypes = map[string]attr.Type{
+	"initial_interval":    types.Int64Type,
+	"max_interval":        types.Int64Type,
+	"multiplier":          types.Float64Type,
+	"jitter":              types.BoolType,
+	"max_elapsed_time":    types.Int64Type,
+	"respect_retry_after": types.BoolType,
+
+	"retry_on_status_codes":      types.ListType{ElemType: types.StringType},
+	"retry_on_connection_errors": types.BoolType,
+	"retry_on_timeouts":          types.BoolType,
+	"retry_on_body_regex":        types.StringType,
+}
+
+// retryPolicy is the evaluated form of a RetryPolicyModel. It is also stored
+// in ephemeral private data, so it must remain JSON serialisable.
+type retryPolicy struct {
+	// InitialInterval is the delay before the first retry. Zero means the
+	// operation's `retry_interval` is used.
+	InitialInterval   time.Duration `json:"initial_interval"`
+	MaxInterval       time.Duration `json:"max_interval"`
+	Multiplier        float64       `json:"multiplier"`
+	Jitter            bool          `json:"jitter"`
+	MaxElapsedTime    time.Duration `json:"max_elapsed_time"`
+	RespectRetryAfter bool          `json:"respect_retry_after"`
+
+	// RetryOnStatusCodes and RetryOnBodyRegex restrict which unexpected
+	// responses are retried. If both are empty, every unexpected response is
+	// retried.
+	RetryOnStatusCodes      []string `json:"retry_on_status_codes,omitempty"`
+	RetryOnBodyRegex        string   `json:"retry_on_body_regex,omitempty"`
+	RetryOnConnectionErrors bool     `json:"retry_on_connection_errors"`
+	RetryOnTimeouts         bool     `json:"retry_on_timeouts"`
+}
+
+func defaultRetryPolicy() *retryPolicy {
+	return &retryPolicy{
+		MaxInterval:       defaultRetryMaxInterval,
+		Multiplier:        defaultRetryMultiplier,
+		Jitter:            true,
+		RespectRetryAfter: true,
+
+		RetryOnConnectionErrors: true,
+		RetryOnTimeouts:         true,
+	}
+}
+
+// retryPolicyFromObject converts a `*retry_policy` block into a retryPolicy.
+// Attributes that are not set are taken from base, usually the provider
+// level policy, and otherwise from the built-in defaults. If the block is not
+// configured, base is returned as is and may be nil, in which case requests
+// are retried every `retry_interval` seconds.
+func retryPolicyFromObject(ctx context.Context, object types.Object, base *retryPolicy) (*retryPolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if object.IsNull() || object.IsUnknown() {
+		return base, diags
+	}
+
+	var model RetryPolicyModel
+	diags.Append(object.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	policy := defaultRetryPolicy()
+	if base != nil {
+		copied := *base
+		policy = &copied
+	}
+
+	if !model.InitialInterval.IsNull() {
+		policy.InitialInterval = time.Duration(model.InitialInterval.ValueInt64()) * time.Second
+	}
+	if !model.MaxInterval.IsNull() {
+		policy.MaxInterval = time.Duration(model.MaxInterval.ValueInt64()) * time.Second
+	}
+	if !model.Multiplier.IsNull() {
+		policy.Multiplier = model.Multiplier.ValueFloat64()
+	}
+	if !model.Jitter.IsNull() {
+		policy.Jitter = model.Jitter.ValueBool()
+	}
+	if !model.MaxElapsedTime.IsNull() {
+		policy.MaxElapsedTime = time.Duration(model.MaxElapsedTime.ValueInt64()) * time.Second
+	}
+	if !model.RespectRetryAfter.IsNull() {
+		policy.RespectRetryAfter = model.RespectRetryAfter.ValueBool()
+	}
+	if !model.RetryOnStatusCodes.IsNull() {
+		policy.RetryOnStatusCodes = nil
+		diags.Append(model.RetryOnStatusCodes.ElementsAs(ctx, &policy.RetryOnStatusCodes, false)...)
+	}
+	if !model.RetryOnConnectionErrors.IsNull() {
+		policy.RetryOnConnectionErrors = model.RetryOnConnectionErrors.ValueBool()
+	}
+	if !model.RetryOnTimeouts.IsNull() {
+		policy.RetryOnTimeouts = model.RetryOnTimeouts.ValueBool()
+	}
+	if !model.RetryOnBodyRegex.IsNull() {
+		policy.RetryOnBodyRegex = model.RetryOnBodyRegex.ValueString()
+	}
+
+	return policy, diags
+}
+
+// backoff returns the delay before retry number retry, starting at 1.
+// fallbackInterval is used as the initial interval when none is configured.
+// result is the response of the failed attempt and may be nil.
+func (p *retryPolicy) backoff(retry int, fallbackInterval time.Duration, result *httpResult) time.Duration {
+	interval := p.InitialInterval
+	if interval <= 0 {
+		interval = fallbackInterval
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := time.Duration(float64(interval) * math.Pow(multiplier, float64(retry-1)))
+	if p.MaxInterval > 0 && (delay > p.MaxInterval || delay <= 0) {
+		delay = p.MaxInterval
+	}
+
+	if p.Jitter && delay > 0 {
+		delay = time.Duration(rand.Int64N(int64(delay) + 1))
+	}
+
+	if p.RespectRetryAfter && result != nil {
+		if serverDelay, ok := retryAfterDelay(result, time.Now()); ok && serverDelay > delay {
+			delay = serverDelay
+		}
+	}
+
+	return delay
+}
+
+// shouldRetry reports whether a failed attempt may be retried. Assertion
+// failures are always retried.
+func (p *retryPolicy) shouldRetry(err error) bool {
+	var statusErr *unexpectedStatusError
+	var assertErr *assertionError
+
+	switch {
+	case errors.As(err, &assertErr):
+		return true
+	case errors.As(err, &statusErr):
+		if len(p.RetryOnStatusCodes) == 0 && p.RetryOnBodyRegex == "" {
+			return true
+		}
+		if len(p.RetryOnStatusCodes) > 0 && responseCodeChecker(p.RetryOnStatusCodes, strconv.Itoa(statusErr.Result.StatusCode)) {
+			return true
+		}
+		if p.RetryOnBodyRegex != "" {
+			matched, _ := regexp.Match(p.RetryOnBodyRegex, statusErr.Result.Body)
+			return matched
+		}
+		return false
+	case isTimeoutError(err):
+		return p.RetryOnTimeouts
+	default:
+		return p.RetryOnConnectionErrors
+	}
+}
+
+// isTimeoutError reports whether err is caused by a request timing out.
+func isTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryAfterDelay returns how long the server asked the client to wait before
+// retrying a 429 or 503 response, based on `Retry-After` or a rate limit reset
+// header.
+func retryAfterDelay(result *httpResult, now time.Time) (time.Duration, bool) {
+	if result.StatusCode != http.StatusTooManyRequests && result.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	if value := strings.TrimSpace(result.Header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return max(date.Sub(now), 0), true
+		}
+	}
+
+	for _, name := range rateLimitResetHeaders {
+		value := strings.TrimSpace(result.Header.Get(name))
+		if value == "" {
+			continue
+		}
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil || seconds < 0 {
+			continue
+		}
+		// Values this large are Unix timestamps rather than a number of seconds.
+		if seconds > 1e9 {
+			return max(time.Unix(int64(seconds), 0).Sub(now), 0), true
+		}
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+
+	return 0, false
+}
+
+const retryPolicyBlockDescription = "Retry policy for the %s call. When set, the wait between attempts grows exponentially instead of staying at `retry_interval`, and the `retry_on_*` attributes control which failures are retried. Unset attributes fall back to the provider's `retry_policy`."
+
+const (
+	retryPolicyInitialIntervalDescription   = "Time in seconds before the first retry. Defaults to `retry_interval` of the operation."
+	retryPolicyMaxIntervalDescription       = "Maximum time in seconds between two attempts. Defaults to 60"
+	retryPolicyMultiplierDescription        = "Factor the wait grows by after each attempt. Defaults to 2"
+	retryPolicyJitterDescription            = "Set this to false to disable full jitter, which picks a random wait between zero and the computed interval. Defaults to true"
+	retryPolicyMaxElapsedTimeDescription    = "Maximum time in seconds spent retrying, including the time taken by the requests. No further attempt is made once the next wait would exceed it. Defaults to no limit"
+	retryPolicyRespectRetryAfterDescription = "Set this to false to ignore the `Retry-After` and rate limit reset headers of 429 and 503 responses. When honoured, the server's wait is used if it is longer than the computed one. Defaults to true"
+
+	retryPolicyRetryOnStatusCodesDescription      = "Unexpected response codes that are retried. Supports the same patterns as `response_codes`. When this or `retry_on_body_regex` is set, any other unexpected response fails immediately. Defaults to retrying every unexpected response code"
+	retryPolicyRetryOnConnectionErrorsDescription = "Set this to false to fail immediately when the connection fails, e.g. when the host cannot be resolved or refuses the connection. Defaults to true"
+	retryPolicyRetryOnTimeoutsDescription         = "Set this to false to fail immediately when a request times out. Defaults to true"
+	retryPolicyRetryOnBodyRegexDescription        = "Regular expression matched against the body of unexpected responses, e.g. `resource is busy`. A matching response is retried regardless of `retry_on_status_codes`."
+)
+
+func retryPolicyProviderBlock() pschema.SingleNestedBlock {
+	return pschema.SingleNestedBlock{
+		MarkdownDescription: "Default retry policy for every request made by this provider. Operations can override individual attributes with their own `*retry_policy` block.",
+		Attributes: map[string]pschema.Attribute{
+			"initial_interval":    pschema.Int64Attribute{Optional: true, MarkdownDescription: retryPolicyInitialIntervalDescription, Validators: nonNegativeSeconds()},
+			"max_interval":        pschema.Int64Attribute{Optional: true, MarkdownDescription: retryPolicyMaxIntervalDescription, Validators: nonNegativeSeconds()},
+			"multiplier":          pschema.Float64Attribute{Optional: true, MarkdownDescription: retryPolicyMultiplierDescription, Validators: retryMultiplierValidators()},
+			"jitter":              pschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyJitterDescription},
+			"max_elapsed_time":    pschema.Int64Attribute{Optional: true, MarkdownDescription: retryPolicyMaxElapsedTimeDescription, Validators: nonNegativeSeconds()},
+			"respect_retry_after": pschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyRespectRetryAfterDescription},
+
+			"retry_on_status_codes":      pschema.ListAttribute{ElementType: types.StringType, Optional: true, MarkdownDescription: retryPolicyRetryOnStatusCodesDescription, Validators: validResponseCodes()},
+			"retry_on_connection_errors": pschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyRetryOnConnectionErrorsDescription},
+			"retry_on_timeouts":          pschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyRetryOnTimeoutsDescription},
+			"retry_on_body_regex":        pschema.StringAttribute{Optional: true, MarkdownDescription: retryPolicyRetryOnBodyRegexDescription, Validators: []validator.String{regexValidator{}}},
+		},
+	}
+}
+
+func retryPolicyResourceBlock(operation string) rschema.SingleNestedBlock {
+	return rschema.SingleNestedBlock{
+		MarkdownDescription: fmt.Sprintf(retryPolicyBlockDescription, operation),
+		Attributes: map[string]rschema.Attribute{
+			"initial_interval":    rschema.Int64Attribute{Optional: true, MarkdownDescription: retryPolicyInitialIntervalDescription, Validators: nonNegativeSeconds()},
+			"max_interval":        rschema.Int64Attribute{Optional: true, MarkdownDescription: retryPolicyMaxIntervalDescription, Validators: nonNegativeSeconds()},
+			"multiplier":          rschema.Float64Attribute{Optional: true, MarkdownDescription: retryPolicyMultiplierDescription, Validators: retryMultiplierValidators()},
+			"jitter":              rschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyJitterDescription},
+			"max_elapsed_time":    rschema.Int64Attribute{Optional: true, MarkdownDescription: retryPolicyMaxElapsedTimeDescription, Validators: nonNegativeSeconds()},
+			"respect_retry_after": rschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyRespectRetryAfterDescription},
+
+			"retry_on_status_codes":      rschema.ListAttribute{ElementType: types.StringType, Optional: true, MarkdownDescription: retryPolicyRetryOnStatusCodesDescription, Validators: validResponseCodes()},
+			"retry_on_connection_errors": rschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyRetryOnConnectionErrorsDescription},
+			"retry_on_timeouts":          rschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyRetryOnTimeoutsDescription},
+			"retry_on_body_regex":        rschema.StringAttribute{Optional: true, MarkdownDescription: retryPolicyRetryOnBodyRegexDescription, Validators: []validator.String{regexValidator{}}},
+		},
+	}
+}
+
+func retryPolicyDataSourceBlock(operation string) dschema.SingleNestedBlock {
+	return dschema.SingleNestedBlock{
+		MarkdownDescription: fmt.Sprintf(retryPolicyBlockDescription, operation),
+		Attributes: map[string]dschema.Attribute{
+			"initial_interval":    dschema.Int64Attribute{Optional: true, MarkdownDescription: retryPolicyInitialIntervalDescription, Validators: nonNegativeSeconds()},
+			"max_interval":        dschema.Int64Attribute{Optional: true, MarkdownDescription: retryPolicyMaxIntervalDescription, Validators: nonNegativeSeconds()},
+			"multiplier":          dschema.Float64Attribute{Optional: true, MarkdownDescription: retryPolicyMultiplierDescription, Validators: retryMultiplierValidators()},
+			"jitter":              dschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyJitterDescription},
+			"max_elapsed_time":    dschema.Int64Attribute{Optional: true, MarkdownDescription: retryPolicyMaxElapsedTimeDescription, Validators: nonNegativeSeconds()},
+			"respect_retry_after": dschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyRespectRetryAfterDescription},
+
+			"retry_on_status_codes":      dschema.ListAttribute{ElementType: types.StringType, Optional: true, MarkdownDescription: retryPolicyRetryOnStatusCodesDescription, Validators: validResponseCodes()},
+			"retry_on_connection_errors": dschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyRetryOnConnectionErrorsDescription},
+			"retry_on_timeouts":          dschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyRetryOnTimeoutsDescription},
+			"retry_on_body_regex":        dschema.StringAttribute{Optional: true, MarkdownDescription: retryPolicyRetryOnBodyRegexDescription, Validators: []validator.String{regexValidator{}}},
+		},
+	}
+}
+
+func retryPolicyEphemeralBlock(operation string) eschema.SingleNestedBlock {
+	return eschema.SingleNestedBlock{
+		MarkdownDescription: fmt.Sprintf(retryPolicyBlockDescription, operation),
+		Attributes: map[string]eschema.Attribute{
+			"initial_interval":    eschema.Int64Attribute{Optional: true, MarkdownDescription: retryPolicyInitialIntervalDescription, Validators: nonNegativeSeconds()},
+			"max_interval":        eschema.Int64Attribute{Optional: true, MarkdownDescription: retryPolicyMaxIntervalDescription, Validators: nonNegativeSeconds()},
+			"multiplier":          eschema.Float64Attribute{Optional: true, MarkdownDescription: retryPolicyMultiplierDescription, Validators: retryMultiplierValidators()},
+			"jitter":              eschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyJitterDescription},
+			"max_elapsed_time":    eschema.Int64Attribute{Optional: true, MarkdownDescription: retryPolicyMaxElapsedTimeDescription, Validators: nonNegativeSeconds()},
+			"respect_retry_after": eschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyRespectRetryAfterDescription},
+
+			"retry_on_status_codes":      eschema.ListAttribute{ElementType: types.StringType, Optional: true, MarkdownDescription: retryPolicyRetryOnStatusCodesDescription, Validators: validResponseCodes()},
+			"retry_on_connection_errors": eschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyRetryOnConnectionErrorsDescription},
+			"retry_on_timeouts":          eschema.BoolAttribute{Optional: true, MarkdownDescription: retryPolicyRetryOnTimeoutsDescription},
+			"retry_on_body_regex":        eschema.StringAttribute{Optional: true, MarkdownDescription: retryPolicyRetryOnBodyRegexDescription, Validators: []validator.String{regexValidator{}}},
+		},
+	}
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &retryPolicy{
+		InitialInterval: time.Second,
+		MaxInterval:     5 * time.Second,
+		Multiplier:      2,
+	}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := policy.backoff(i+1, 0, nil); got != want {
+			t.Errorf("retry %d: expected %s, got %s", i+1, want, got)
+		}
+	}
+
+	// The operation's retry_interval is used when no initial interval is set.
+	policy.InitialInterval = 0
+	if got := policy.backoff(1, 3*time.Second, nil); got != 3*time.Second {
+		t.Errorf("expected fallback interval of 3s, got %s", got)
+	}
+
+	policy.Jitter = true
+	for i := 0; i < 100; i++ {
+		if got := policy.backoff(3, time.Second, nil); got < 0 || got > 4*time.Second {
+			t.Fatalf("jittered delay %s is outside [0s, 4s]", got)
+		}
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		statusCode int
+		header     http.Header
+		expected   time.Duration
+		found      bool
+	}{
+		{name: "Seconds", statusCode: 429, header: http.Header{"Retry-After": []string{"30"}}, expected: 30 * time.Second, found: true},
+		{name: "HTTP date", statusCode: 503, header: http.Header{"Retry-After": []string{now.Add(time.Minute).Format(http.TimeFormat)}}, expected: time.Minute, found: true},
+		{name: "Date in the past", statusCode: 503, header: http.Header{"Retry-After": []string{now.Add(-time.Minute).Format(http.TimeFormat)}}, expected: 0, found: true},
+		{name: "Rate limit reset seconds", statusCode: 429, header: http.Header{"Ratelimit-Reset": []string{"12"}}, expected: 12 * time.Second, found: true},
+		{name: "Rate limit reset timestamp", statusCode: 429, header: http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(now.Add(90*time.Second).Unix(), 10)}}, expected: 90 * time.Second, found: true},
+		{name: "Ignored for other status codes", statusCode: 500, header: http.Header{"Retry-After": []string{"30"}}, found: false},
+		{name: "Invalid value", statusCode: 429, header: http.Header{"Retry-After": []string{"soon"}}, found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, found := retryAfterDelay(&httpResult{StatusCode: tt.statusCode, Header: tt.header}, now)
+			if found != tt.found || delay != tt.expected {
+				t.Errorf("expected (%s, %v), got (%s, %v)", tt.expected, tt.found, delay, found)
+			}
+		})
+	}
+}
+
+func TestExecuteRequestRetryDeadline(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	policy := defaultRetryPolicy()
+	policy.MaxElapsedTime = 5 * time.Second
+
+	start := time.Now()
+	_, err = executeRequest(context.Background(), server.Client(), request, requestOptions{
+		Operation:     "Create",
+		MaxRetry:      10,
+		RetryInterval: time.Millisecond,
+		ResponseCodes: []string{"200"},
+		RetryPolicy:   policy,
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if attempts != 1 {
+		t.Errorf("expected the Retry-After wait to exceed the deadline after 1 attempt, got %d attempts", attempts)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("expected to give up without waiting, took %s", time.Since(start))
+	}
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	status := func(code int, body string) error {
+		return &unexpectedStatusError{Result: &httpResult{StatusCode: code, Body: []byte(body)}}
+	}
+
+	tests := []struct {
+		name     string
+		policy   func(p *retryPolicy)
+		err      error
+		expected bool
+	}{
+		{name: "Any status code by default", err: status(400, ""), expected: true},
+		{name: "Listed status code", policy: func(p *retryPolicy) { p.RetryOnStatusCodes = []string{"429", "5xx"} }, err: status(503, ""), expected: true},
+		{name: "Unlisted status code", policy: func(p *retryPolicy) { p.RetryOnStatusCodes = []string{"429", "5xx"} }, err: status(400, ""), expected: false},
+		{name: "Body regex", policy: func(p *retryPolicy) { p.RetryOnBodyRegex = "resource is busy" }, err: status(409, `{"error":"resource is busy"}`), expected: true},
+		{name: "Body regex mismatch", policy: func(p *retryPolicy) { p.RetryOnBodyRegex = "resource is busy" }, err: status(409, `{"error":"conflict"}`), expected: false},
+		{name: "Assertion failures", policy: func(p *retryPolicy) { p.RetryOnStatusCodes = []string{"503"} }, err: &assertionError{Result: &httpResult{StatusCode: 200}}, expected: true},
+		{name: "Timeouts", policy: func(p *retryPolicy) { p.RetryOnTimeouts = false }, err: context.DeadlineExceeded, expected: false},
+		{name: "Connection errors", policy: func(p *retryPolicy) { p.RetryOnConnectionErrors = false }, err: errors.New("connection refused"), expected: false},
+		{name: "Connection errors by default", err: errors.New("connection refused"), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := defaultRetryPolicy()
+			if tt.policy != nil {
+				tt.policy(policy)
+			}
+			if got := policy.shouldRetry(tt.err); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExecuteRequestFailsFastOnNonRetryableStatus(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid name"}`))
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	policy := defaultRetryPolicy()
+	policy.RetryOnStatusCodes = []string{"5xx"}
+
+	_, err = executeRequest(context.Background(), server.Client(), request, requestOptions{
+		Operation:     "Create",
+		MaxRetry:      5,
+		RetryInterval: time.Millisecond,
+		ResponseCodes: []string{"2xx"},
+		RetryPolicy:   policy,
+	})
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+	if err == nil || !strings.Contains(err.Error(), `not retryable. Response: {"error":"invalid name"}`) {
+		t.Errorf("expected a non-retryable error with the response body, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// responseTemplateData is the data available to templated request fields such
+// as `wait_for.url`. A field such as `{{ .Body.id }}` refers to the decoded
+// JSON body of the response the template is rendered against.
+type responseTemplateData struct {
+	// Body is the decoded JSON response body, or nil if the body is not JSON.
+	Body interface{}
+	// RawBody is the response body as received.
+	RawBody string
+	// Headers holds the first value of every response header, keyed by its
+	// canonical name, e.g. `Location` or `X-Request-Id`.
+	Headers map[string]string
+	// Location is the `Location` response header resolved against the
+	// request URL, or an empty string if the header is missing.
+	Location   string
+	StatusCode int
+}
+
+// newResponseTemplateData builds the template data for result. requestURL is
+// used to resolve a relative `Location` header and may be nil.
+func newResponseTemplateData(result *httpResult, requestURL *url.URL) responseTemplateData {
+	data := responseTemplateData{
+		RawBody:    string(result.Body),
+		Headers:    map[string]string{},
+		StatusCode: result.StatusCode,
+	}
+
+	if document, err := decodeJSON(result.Body); err == nil {
+		data.Body = document
+	}
+
+	for name, values := range result.Header {
+		if len(values) > 0 {
+			data.Headers[name] = values[0]
+		}
+	}
+
+	if location := result.Header.Get("Location"); location != "" {
+		data.Location = location
+		if requestURL != nil {
+			if parsed, err := url.Parse(location); err == nil {
+				data.Location = requestURL.ResolveReference(parsed).String()
+			}
+		}
+	}
+
+	return data
+}
+
+// renderTemplate renders text as a Go template against data, usually a
+// responseTemplateData. Text without template actions is returned unchanged,
+// and referring to a missing key is an error rather than rendering
+// `<no value>`.
+func renderTemplate(text string, data interface{}) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New("request").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %q: %w", text, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render template %q: %w", text, err)
+	}
+	return rendered.String(), nil
+}
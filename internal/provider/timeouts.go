@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	eschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const timeoutDescription = "Maximum time for the whole %s operation, including every retry and any polling, as a [duration](https://pkg.go.dev/time#ParseDuration) such as `30s` or `2h45m`. Defaults to no limit"
+
+// resourceTimeoutsAttrTypes are the attribute types of the resource
+// `timeouts` block object.
+var resourceTimeoutsAttrTypes = map[string]attr.Type{
+	"create": types.StringType,
+	"read":   types.StringType,
+	"update": types.StringType,
+	"delete": types.StringType,
+}
+
+// withOperationTimeout bounds ctx by timeout. A zero timeout leaves the
+// operation unbounded.
+func withOperationTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// EphemeralTimeoutsModel describes the ephemeral resource `timeouts` block.
+// terraform-plugin-framework-timeouts does not provide one for ephemeral
+// resources.
+type EphemeralTimeoutsModel struct {
+	Open  types.String `tfsdk:"open"`
+	Renew types.String `tfsdk:"renew"`
+	Close types.String `tfsdk:"close"`
+}
+
+// ephemeralTimeoutsAttrTypes are the attribute types of the ephemeral
+// `timeouts` block object.
+var ephemeralTimeoutsAttrTypes = map[string]attr.Type{
+	"open":  types.StringType,
+	"renew": types.StringType,
+	"close": types.StringType,
+}
+
+func ephemeralTimeoutsBlock() eschema.SingleNestedBlock {
+	attribute := func(operation string) eschema.StringAttribute {
+		return eschema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf(timeoutDescription, operation),
+			Validators:          []validator.String{durationValidator{}},
+		}
+	}
+
+	return eschema.SingleNestedBlock{
+		MarkdownDescription: "Overall deadlines for the open, renew and close operations.",
+		Attributes: map[string]eschema.Attribute{
+			"open":  attribute("open"),
+			"renew": attribute("renew"),
+			"close": attribute("close"),
+		},
+	}
+}
+
+// ephemeralTimeouts holds the evaluated ephemeral `timeouts` block. A zero
+// duration means no limit.
+type ephemeralTimeouts struct {
+	Open  time.Duration
+	Renew time.Duration
+	Close time.Duration
+}
+
+// ephemeralTimeoutsFromObject converts the ephemeral `timeouts` block into
+// durations.
+func ephemeralTimeoutsFromObject(ctx context.Context, object types.Object) (ephemeralTimeouts, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var timeouts ephemeralTimeouts
+	if object.IsNull() || object.IsUnknown() {
+		return timeouts, diags
+	}
+
+	var model EphemeralTimeoutsModel
+	diags.Append(object.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return timeouts, diags
+	}
+
+	parse := func(name string, value types.String, target *time.Duration) {
+		if value.IsNull() || value.IsUnknown() {
+			return
+		}
+		duration, err := time.ParseDuration(value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("timeouts").AtName(name), "Invalid Timeout", err.Error())
+			return
+		}
+		*target = duration
+	}
+	parse("open", model.Open, &timeouts.Open)
+	parse("renew", model.Renew, &timeouts.Renew)
+	parse("close", model.Close, &timeouts.Close)
+
+	return timeouts, diags
+}
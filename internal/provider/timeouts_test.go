@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEphemeralTimeoutsFromObject(t *testing.T) {
+	ctx := context.Background()
+
+	object := types.ObjectValueMust(ephemeralTimeoutsAttrTypes, map[string]attr.Value{
+		"open":  types.StringValue("30s"),
+		"renew": types.StringNull(),
+		"close": types.StringValue("2m"),
+	})
+	timeouts, diags := ephemeralTimeoutsFromObject(ctx, object)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if timeouts.Open != 30*time.Second || timeouts.Renew != 0 || timeouts.Close != 2*time.Minute {
+		t.Errorf("unexpected timeouts %+v", timeouts)
+	}
+
+	timeouts, diags = ephemeralTimeoutsFromObject(ctx, types.ObjectNull(ephemeralTimeoutsAttrTypes))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if timeouts != (ephemeralTimeouts{}) {
+		t.Errorf("expected no limits for a null block, got %+v", timeouts)
+	}
+}
+
+func TestWithOperationTimeout(t *testing.T) {
+	ctx, cancel := withOperationTimeout(context.Background(), 0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline for a zero timeout")
+	}
+
+	ctx, cancel = withOperationTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, ok := ctx.Deadline(); !ok {
+		t.Error("expected a deadline")
+	}
+}
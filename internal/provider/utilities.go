@@ -5,11 +5,11 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"net/http"
 	"os"
-	"time"
+	"strconv"
+	"strings"
 )
 
 func sanitizeResponse(response string, fieldsToIgnore []string) (string, error) {
@@ -39,14 +39,107 @@ func sanitizeResponse(response string, fieldsToIgnore []string) (string, error)
 	return string(filteredBytes), nil
 }
 
+// responseCodePattern is a parsed entry of a `*_response_codes` list.
+type responseCodePattern struct {
+	min    int
+	max    int
+	negate bool
+}
+
+func (p responseCodePattern) matches(code int) bool {
+	return code >= p.min && code <= p.max
+}
+
+// parseResponseCodePattern parses an exact status code ("200"), a status class
+// ("2xx"), an inclusive range ("200-299") or a negation of any of these ("!409").
+func parseResponseCodePattern(raw string) (responseCodePattern, error) {
+	var pattern responseCodePattern
+
+	value := strings.TrimSpace(raw)
+	if strings.HasPrefix(value, "!") {
+		pattern.negate = true
+		value = strings.TrimSpace(strings.TrimPrefix(value, "!"))
+	}
+
+	switch {
+	case len(value) == 3 && strings.HasSuffix(strings.ToLower(value), "xx"):
+		class, err := strconv.Atoi(value[:1])
+		if err != nil || class < 1 || class > 5 {
+			return pattern, fmt.Errorf("invalid status class %q, expected one of 1xx, 2xx, 3xx, 4xx or 5xx", raw)
+		}
+		pattern.min = class * 100
+		pattern.max = class*100 + 99
+	case strings.Contains(value, "-"):
+		bounds := strings.SplitN(value, "-", 2)
+		lower, err := parseStatusCode(bounds[0])
+		if err != nil {
+			return pattern, fmt.Errorf("invalid status range %q: %v", raw, err)
+		}
+		upper, err := parseStatusCode(bounds[1])
+		if err != nil {
+			return pattern, fmt.Errorf("invalid status range %q: %v", raw, err)
+		}
+		if lower > upper {
+			return pattern, fmt.Errorf("invalid status range %q: lower bound is greater than upper bound", raw)
+		}
+		pattern.min = lower
+		pattern.max = upper
+	default:
+		code, err := parseStatusCode(value)
+		if err != nil {
+			return pattern, fmt.Errorf("invalid status code %q: %v", raw, err)
+		}
+		pattern.min = code
+		pattern.max = code
+	}
+
+	return pattern, nil
+}
+
+func parseStatusCode(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	code, err := strconv.Atoi(value)
+	if err != nil || len(value) != 3 {
+		return 0, fmt.Errorf("%q is not a three digit status code", value)
+	}
+	if code < 100 || code > 599 {
+		return 0, fmt.Errorf("%d is outside of the 100-599 range", code)
+	}
+	return code, nil
+}
+
+// responseCodeChecker reports whether the status code str is accepted by the
+// patterns in s. A matching negation always rejects the code. When s only holds
+// negations, every other status code is accepted. Entries which fail to parse
+// never match.
 func responseCodeChecker(s []string, str string) bool {
+	code, err := strconv.Atoi(str)
+	if err != nil {
+		return false
+	}
+
+	matched := false
+	hasPositive := false
+	hasNegative := false
 	for _, v := range s {
-		if v == str {
-			return true
+		pattern, err := parseResponseCodePattern(v)
+		if err != nil {
+			continue
+		}
+		if pattern.negate {
+			if pattern.matches(code) {
+				return false
+			}
+			hasNegative = true
+			continue
+		}
+		hasPositive = true
+		if pattern.matches(code) {
+			matched = true
 		}
 	}
 
-	return false
+	return matched || (!hasPositive && hasNegative)
 }
 
 type TlsConfig struct {
@@ -100,7 +193,6 @@ func createTlsClient(cfg *TlsConfig) (*http.Client, error) {
 		Transport: &http.Transport{
 			TLSClientConfig: tlsConfig,
 		},
-		Timeout: 30 * time.Second,
 	}, nil
 }
 
@@ -118,22 +210,6 @@ func convertMap(tfMap types.Map) map[string]string {
 	return goMap
 }
 
-func convertStringSliceToTFValues(input []string) []attr.Value {
-	values := make([]attr.Value, len(input))
-	for i, str := range input {
-		values[i] = types.StringValue(str)
-	}
-	return values
-}
-
-func convertStringMapToTFValues(input map[string]string) map[string]attr.Value {
-	result := make(map[string]attr.Value)
-	for k, v := range input {
-		result[k] = types.StringValue(v)
-	}
-	return result
-}
-
 func hasValue(s types.String) bool {
 	return !s.IsNull() && s.ValueString() != ""
 }
@@ -57,6 +57,18 @@ func TestResponseCodeChecker(t *testing.T) {
 	}{
 		{"Value Present", []string{"200", "404", "500"}, "404", true},
 		{"Value Absent", []string{"200", "500"}, "404", false},
+		{"Class Match", []string{"2xx"}, "204", true},
+		{"Class Match Upper Case", []string{"2XX"}, "201", true},
+		{"Class Mismatch", []string{"2xx"}, "301", false},
+		{"Range Match", []string{"200-299"}, "250", true},
+		{"Range Bounds Inclusive", []string{"200-204"}, "204", true},
+		{"Range Mismatch", []string{"200-204"}, "205", false},
+		{"Negation Wins Over Class", []string{"4xx", "!409"}, "409", false},
+		{"Negation Leaves Class", []string{"4xx", "!409"}, "404", true},
+		{"Only Negations", []string{"!409"}, "500", true},
+		{"Only Negations Match", []string{"!5xx"}, "503", false},
+		{"Junk Never Matches", []string{"abc"}, "200", false},
+		{"Non Numeric Status", []string{"2xx"}, "OK", false},
 	}
 
 	for _, tt := range tests {
@@ -69,6 +81,34 @@ func TestResponseCodeChecker(t *testing.T) {
 	}
 }
 
+func TestParseResponseCodePattern(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expectErr bool
+	}{
+		{"Exact Code", "200", false},
+		{"Class", "5xx", false},
+		{"Range", "200-299", false},
+		{"Negated Range", "!400-403", false},
+		{"Non Numeric", "ok", true},
+		{"Two Digit Code", "20", true},
+		{"Out Of Range Code", "600", true},
+		{"Unknown Class", "9xx", true},
+		{"Inverted Range", "299-200", true},
+		{"Empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseResponseCodePattern(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
 func TestCreateTlsClient(t *testing.T) {
 	t.Run("Default Config", func(t *testing.T) {
 		cfg := defaultTlsConfig()
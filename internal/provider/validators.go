@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = responseCodeValidator{}
+
+// responseCodeValidator checks that a value is a response code pattern understood
+// by responseCodeChecker.
+type responseCodeValidator struct{}
+
+func (v responseCodeValidator) Description(_ context.Context) string {
+	return "value must be a status code (200), a status class (2xx), an inclusive range (200-299) or a negation of one of these (!409)"
+}
+
+func (v responseCodeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v responseCodeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseResponseCodePattern(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Response Code",
+			err.Error(),
+		)
+	}
+}
+
+// nonNegativeSeconds validates attributes holding a number of seconds.
+func nonNegativeSeconds() []validator.Int64 {
+	return []validator.Int64{
+		int64validator.AtLeast(0),
+	}
+}
+
+// retryMultiplierValidators keeps the retry backoff from shrinking.
+func retryMultiplierValidators() []validator.Float64 {
+	return []validator.Float64{
+		float64validator.AtLeast(1),
+	}
+}
+
+// validResponseCodes validates every element of a `*_response_codes` list.
+func validResponseCodes() []validator.List {
+	return []validator.List{
+		listvalidator.ValueStringsAre(responseCodeValidator{}),
+	}
+}
+
+var _ validator.String = regexValidator{}
+
+// regexValidator checks that a value is a valid regular expression.
+type regexValidator struct{}
+
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			err.Error(),
+		)
+	}
+}
+
+var _ validator.String = jsonPathValidator{}
+
+// jsonPathValidator checks that a value is a JSON path understood by
+// lookupJSONPath.
+type jsonPathValidator struct{}
+
+func (v jsonPathValidator) Description(_ context.Context) string {
+	return "value must be a JSON path such as `data.items[0].id`"
+}
+
+func (v jsonPathValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonPathValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseJSONPath(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON Path",
+			err.Error(),
+		)
+	}
+}
+
+var _ validator.String = templateValidator{}
+
+// templateValidator checks that a value is a valid Go template for
+// renderTemplate.
+type templateValidator struct{}
+
+func (v templateValidator) Description(_ context.Context) string {
+	return "value must be a valid Go template such as `https://api.example.com/operations/{{ .Body.id }}`"
+}
+
+func (v templateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v templateValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := template.New("request").Parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Template",
+			err.Error(),
+		)
+	}
+}
+
+var _ validator.String = durationValidator{}
+
+// durationValidator checks that a value can be parsed by time.ParseDuration.
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a duration such as 30s or 2h45m"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			err.Error(),
+		)
+	}
+}
@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultWaitForInterval = 5 * time.Second
+	defaultWaitForTimeout  = 5 * time.Minute
+)
+
+// WaitForModel describes the `wait_for` block.
+type WaitForModel struct {
+	Url           types.String `tfsdk:"url"`
+	Method        types.String `tfsdk:"method"`
+	Headers       types.Map    `tfsdk:"headers"`
+	Path          types.String `tfsdk:"path"`
+	SuccessValues types.List   `tfsdk:"success_values"`
+	FailureValues types.List   `tfsdk:"failure_values"`
+	ResponseCodes types.List   `tfsdk:"response_codes"`
+	Interval      types.Int64  `tfsdk:"interval"`
+	Timeout       types.Int64  `tfsdk:"timeout"`
+}
+
+// waitForAttrTypes are the attribute types of the `wait_for` block object.
+var waitForAttrTypes = map[string]attr.Type{
+	"url":            types.StringType,
+	"method":         types.StringType,
+	"headers":        types.MapType{ElemType: types.StringType},
+	"path":           types.StringType,
+	"success_values": types.ListType{ElemType: types.StringType},
+	"failure_values": types.ListType{ElemType: types.StringType},
+	"response_codes": types.ListType{ElemType: types.StringType},
+	"interval":       types.Int64Type,
+	"timeout":        types.Int64Type,
+}
+
+func waitForResourceBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Polls a status endpoint after the create or update call until the operation has finished. The resource is only saved once the value at `path` is one of `success_values`.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "URL to poll. Supports Go templates against the create or update response, e.g. `https://api.example.com/operations/{{ .Body.id }}` or `{{ .Location }}`, the `Location` header resolved against the request URL. Defaults to the `Location` header of the response.",
+				Validators:          []validator.String{templateValidator{}},
+			},
+			"method": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "HTTP method to use when polling. Defaults to `GET`.",
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Map of headers to attach to each poll request. Values support the same templates as `url`.",
+			},
+			"path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "JSON path into the poll response whose value is compared with `success_values` and `failure_values`, e.g. `status`.",
+				Validators:          []validator.String{jsonPathValidator{}},
+			},
+			"success_values": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Values at `path` that mean the operation has finished.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"failure_values": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Values at `path` that mean the operation has failed. Polling stops immediately when one is seen.",
+			},
+			"response_codes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Response codes that mean the status endpoint answered. Any other code is treated as not ready yet. Defaults to `2xx`.",
+				Validators:          validResponseCodes(),
+			},
+			"interval": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Time in seconds between each poll. Defaults to 5",
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"timeout": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Time in seconds to wait for a success value before failing. Defaults to 300",
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+		},
+	}
+}
+
+// waitForConfig is the evaluated form of a WaitForModel.
+type waitForConfig struct {
+	Url           string
+	Method        string
+	Headers       map[string]string
+	Path          string
+	SuccessValues []string
+	FailureValues []string
+	ResponseCodes []string
+	Interval      time.Duration
+	Timeout       time.Duration
+}
+
+// waitForFromObject converts a `wait_for` block into a waitForConfig. It
+// returns nil if the block is not configured.
+func waitForFromObject(ctx context.Context, object types.Object) (*waitForConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if object.IsNull() || object.IsUnknown() {
+		return nil, diags
+	}
+
+	var model WaitForModel
+	diags.Append(object.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	config := &waitForConfig{
+		Url:           model.Url.ValueString(),
+		Method:        model.Method.ValueString(),
+		Headers:       convertMap(model.Headers),
+		Path:          model.Path.ValueString(),
+		ResponseCodes: []string{"2xx"},
+		Interval:      defaultWaitForInterval,
+		Timeout:       defaultWaitForTimeout,
+	}
+	if config.Method == "" {
+		config.Method = http.MethodGet
+	}
+	diags.Append(model.SuccessValues.ElementsAs(ctx, &config.SuccessValues, false)...)
+	if !model.FailureValues.IsNull() {
+		diags.Append(model.FailureValues.ElementsAs(ctx, &config.FailureValues, false)...)
+	}
+	if !model.ResponseCodes.IsNull() {
+		diags.Append(model.ResponseCodes.ElementsAs(ctx, &config.ResponseCodes, false)...)
+	}
+	if !model.Interval.IsNull() {
+		config.Interval = time.Duration(model.Interval.ValueInt64()) * time.Second
+	}
+	if !model.Timeout.IsNull() {
+		config.Timeout = time.Duration(model.Timeout.ValueInt64()) * time.Second
+	}
+
+	return config, diags
+}
+
+// waitFailedError is returned when the status endpoint reports one of the
+// configured failure values.
+type waitFailedError struct {
+	Path   string
+	Value  string
+	Result *httpResult
+}
+
+func (e *waitFailedError) Error() string {
+	return fmt.Sprintf("The status endpoint reported failure: %s is %q. Response: %s", e.Path, e.Value, string(e.Result.Body))
+}
+
+// waitTimeoutError is returned when no success value was seen before the
+// `wait_for` timeout.
+type waitTimeoutError struct {
+	Timeout   time.Duration
+	LastState string
+}
+
+func (e *waitTimeoutError) Error() string {
+	return fmt.Sprintf("The operation did not finish within %s. Last poll: %s", e.Timeout, e.LastState)
+}
+
+// pollUntilReady polls the `wait_for` endpoint until the value at the
+// configured path is a success value, a failure value is seen or the timeout
+// expires. source is the create or update response that templates are
+// rendered against and sourceURL is the URL it was received from.
+func pollUntilReady(ctx context.Context, client *http.Client, config *waitForConfig, source *httpResult, sourceURL *url.URL, attemptTimeout time.Duration) (*httpResult, error) {
+	templateData := newResponseTemplateData(source, sourceURL)
+
+	// The Location header is server data and is used as received; only the
+	// configured URL is a template.
+	pollUrl := templateData.Location
+	if config.Url != "" {
+		rendered, err := renderTemplate(config.Url, templateData)
+		if err != nil {
+			return nil, err
+		}
+		pollUrl = rendered
+	} else if pollUrl == "" {
+		return nil, errors.New("`wait_for.url` is not set and the response did not include a `Location` header")
+	}
+
+	request, err := http.NewRequest(config.Method, pollUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create poll request: %w", err)
+	}
+	for name, value := range config.Headers {
+		rendered, err := renderTemplate(value, templateData)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set(name, rendered)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	lastState := "no response received"
+	for attempt := 0; ; attempt++ {
+		result, err := sendAttempt(waitCtx, client, request, attemptTimeout, attempt)
+		switch {
+		case err != nil:
+			// An attempt cut short by the wait timeout says nothing about
+			// the operation, so keep the previous poll's state.
+			if waitCtx.Err() == nil || attempt == 0 {
+				lastState = err.Error()
+			}
+		case !responseCodeChecker(config.ResponseCodes, strconv.Itoa(result.StatusCode)):
+			lastState = fmt.Sprintf("received status code %d", result.StatusCode)
+		default:
+			value, found, err := lookupResponseValue(result.Body, config.Path)
+			if err != nil {
+				lastState = err.Error()
+				break
+			}
+			if !found {
+				lastState = fmt.Sprintf("%s is absent", config.Path)
+				break
+			}
+			if slices.Contains(config.SuccessValues, value) {
+				return result, nil
+			}
+			if slices.Contains(config.FailureValues, value) {
+				return result, &waitFailedError{Path: config.Path, Value: value, Result: result}
+			}
+			lastState = fmt.Sprintf("%s is %q", config.Path, value)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for %s to finish: %s", request.URL.String(), lastState))
+
+		timer := time.NewTimer(config.Interval)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			if ctx.Err() != nil {
+				return nil, &interruptedError{Operation: "Wait", Cause: ctx.Err(), LastErr: errors.New(lastState)}
+			}
+			return nil, &waitTimeoutError{Timeout: config.Timeout, LastState: lastState}
+		case <-timer.C:
+		}
+	}
+}
+
+// lookupResponseValue returns the value at path in a JSON response body as a
+// string.
+func lookupResponseValue(body []byte, path string) (string, bool, error) {
+	document, err := decodeJSON(body)
+	if err != nil {
+		return "", false, fmt.Errorf("response body is not JSON: %w", err)
+	}
+	value, found, err := lookupJSONPath(document, path)
+	if err != nil || !found {
+		return "", found, err
+	}
+	return jsonValueString(value), true, nil
+}
+
+// addWaitError reports an error returned by pollUntilReady.
+func addWaitError(diags *diag.Diagnostics, err error) {
+	var failedErr *waitFailedError
+	var timeoutErr *waitTimeoutError
+	var interruptErr *interruptedError
+
+	switch {
+	case errors.As(err, &interruptErr):
+		diags.AddError("Wait Interrupted", interruptErr.Error())
+	case errors.As(err, &failedErr):
+		diags.AddError("Wait Failed", failedErr.Error())
+	case errors.As(err, &timeoutErr):
+		diags.AddError("Wait Timeout", timeoutErr.Error())
+	default:
+		diags.AddError("Wait Failed", err.Error())
+	}
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestPollUntilReady(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/operations/op-1":
+			polls++
+			if r.Header.Get("X-Operation") != "op-1" {
+				t.Errorf("expected templated header, got %q", r.Header.Get("X-Operation"))
+			}
+			if polls < 3 {
+				_, _ = w.Write([]byte(`{"state":"PENDING"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"state":"READY"}`))
+		case "/operations/op-2":
+			_, _ = w.Write([]byte(`{"state":"FAILED"}`))
+		case "/operations/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/operations/op-3":
+			if r.URL.Query().Get("code") != "{{.StatusCode}}" {
+				_, _ = w.Write([]byte(`{"state":"FAILED"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"state":"READY"}`))
+		}
+	}))
+	defer server.Close()
+
+	sourceURL, _ := url.Parse(server.URL + "/things")
+	source := func(id string) *httpResult {
+		return &httpResult{
+			StatusCode: http.StatusAccepted,
+			Body:       []byte(`{"operation":"` + id + `"}`),
+			Header:     http.Header{"Location": []string{"/operations/" + id}},
+		}
+	}
+
+	t.Run("Templated URL polls until success", func(t *testing.T) {
+		config := &waitForConfig{
+			Url:           server.URL + "/operations/{{ .Body.operation }}",
+			Method:        http.MethodGet,
+			Headers:       map[string]string{"X-Operation": "{{ .Body.operation }}"},
+			Path:          "state",
+			SuccessValues: []string{"READY"},
+			ResponseCodes: []string{"2xx"},
+			Interval:      time.Millisecond,
+			Timeout:       5 * time.Second,
+		}
+		result, err := pollUntilReady(context.Background(), server.Client(), config, source("op-1"), sourceURL, time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if polls != 3 || string(result.Body) != `{"state":"READY"}` {
+			t.Errorf("expected 3 polls ending in READY, got %d polls and %q", polls, result.Body)
+		}
+	})
+
+	t.Run("Location header and failure value", func(t *testing.T) {
+		config := &waitForConfig{
+			Method:        http.MethodGet,
+			Path:          "state",
+			SuccessValues: []string{"READY"},
+			FailureValues: []string{"FAILED"},
+			ResponseCodes: []string{"2xx"},
+			Interval:      time.Millisecond,
+			Timeout:       5 * time.Second,
+		}
+		_, err := pollUntilReady(context.Background(), server.Client(), config, source("op-2"), sourceURL, time.Second)
+		var failedErr *waitFailedError
+		if !errors.As(err, &failedErr) || failedErr.Value != "FAILED" {
+			t.Errorf("expected waitFailedError with FAILED, got %v", err)
+		}
+	})
+
+	t.Run("Location header is not templated", func(t *testing.T) {
+		config := &waitForConfig{
+			Method:        http.MethodGet,
+			Path:          "state",
+			SuccessValues: []string{"READY"},
+			FailureValues: []string{"FAILED"},
+			ResponseCodes: []string{"2xx"},
+			Interval:      time.Millisecond,
+			Timeout:       5 * time.Second,
+		}
+		templated := source("op-3")
+		templated.Header.Set("Location", "/operations/op-3?code={{.StatusCode}}")
+		result, err := pollUntilReady(context.Background(), server.Client(), config, templated, sourceURL, time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(result.Body) != `{"state":"READY"}` {
+			t.Errorf("expected the Location header to be polled as received, got %q", result.Body)
+		}
+	})
+
+	t.Run("Timeout reports last poll", func(t *testing.T) {
+		config := &waitForConfig{
+			Url:           server.URL + "/operations/missing",
+			Method:        http.MethodGet,
+			Path:          "state",
+			SuccessValues: []string{"READY"},
+			ResponseCodes: []string{"2xx"},
+			Interval:      10 * time.Millisecond,
+			Timeout:       50 * time.Millisecond,
+		}
+		_, err := pollUntilReady(context.Background(), server.Client(), config, source("missing"), sourceURL, time.Second)
+		var timeoutErr *waitTimeoutError
+		if !errors.As(err, &timeoutErr) || timeoutErr.LastState != "received status code 404" {
+			t.Errorf("expected waitTimeoutError after a 404, got %v", err)
+		}
+	})
+}
+
+func TestRenderTemplate(t *testing.T) {
+	requestURL, _ := url.Parse("https://api.example.com/v1/things")
+	data := newResponseTemplateData(&httpResult{
+		StatusCode: http.StatusCreated,
+		Body:       []byte(`{"id":"abc","items":[{"name":"first"}]}`),
+		Header:     http.Header{"Location": []string{"/v1/things/abc"}, "X-Request-Id": []string{"req-1"}},
+	}, requestURL)
+
+	tests := []struct {
+		name      string
+		text      string
+		expected  string
+		expectErr bool
+	}{
+		{name: "Plain text", text: "https://example.com", expected: "https://example.com"},
+		{name: "Body field", text: "/things/{{ .Body.id }}", expected: "/things/abc"},
+		{name: "Nested list", text: `{{ (index .Body.items 0).name }}`, expected: "first"},
+		{name: "Resolved location", text: "{{ .Location }}", expected: "https://api.example.com/v1/things/abc"},
+		{name: "Header", text: `{{ index .Headers "X-Request-Id" }}`, expected: "req-1"},
+		{name: "Missing key", text: "{{ .Body.missing }}", expectErr: true},
+		{name: "Invalid template", text: "{{ .Body.id ", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := renderTemplate(tt.text, data)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got %q", rendered)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rendered != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, rendered)
+			}
+		})
+	}
+}
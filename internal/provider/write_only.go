@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// writeOnlyArguments holds the write-only counterparts of the headers and
+// request body of a request. They are only available in the configuration,
+// never in the plan or state.
+type writeOnlyArguments struct {
+	Headers     types.Map
+	RequestBody types.String
+}
+
+// getWriteOnlyArguments reads the write-only headers and body attributes from
+// config.
+func getWriteOnlyArguments(ctx context.Context, config tfsdk.Config, headersAttribute string, bodyAttribute string) (writeOnlyArguments, diag.Diagnostics) {
+	var arguments writeOnlyArguments
+	diags := config.GetAttribute(ctx, path.Root(headersAttribute), &arguments.Headers)
+	diags.Append(config.GetAttribute(ctx, path.Root(bodyAttribute), &arguments.RequestBody)...)
+	return arguments, diags
+}
+
+// body returns the write-only request body if set, otherwise requestBody.
+func (a writeOnlyArguments) body(requestBody types.String) []byte {
+	if !a.RequestBody.IsNull() && !a.RequestBody.IsUnknown() {
+		return []byte(a.RequestBody.ValueString())
+	}
+	return []byte(requestBody.ValueString())
+}
+
+// setHeaders adds the write-only headers to request. They take precedence
+// over headers of the same name set from regular attributes.
+func (a writeOnlyArguments) setHeaders(request *http.Request) {
+	if a.Headers.IsNull() || a.Headers.IsUnknown() {
+		return
+	}
+	for k, v := range a.Headers.Elements() {
+		if strVal, ok := v.(types.String); ok {
+			request.Header.Set(k, strVal.ValueString())
+		}
+	}
+}
+
+// secrets returns the write-only header and body values, which are always
+// masked in logs.
+func (a writeOnlyArguments) secrets() []string {
+	var secrets []string
+	for _, v := range a.Headers.Elements() {
+		if strVal, ok := v.(types.String); ok {
+			secrets = append(secrets, strVal.ValueString())
+		}
+	}
+	if !a.RequestBody.IsNull() && !a.RequestBody.IsUnknown() {
+		secrets = append(secrets, a.RequestBody.ValueString())
+	}
+	return secrets
+}
+
+// loggedBody returns the request body to write to the debug log. A write-only
+// body is never logged.
+func (a writeOnlyArguments) loggedBody(requestBody types.String) string {
+	if !a.RequestBody.IsNull() && !a.RequestBody.IsUnknown() {
+		return maskedValue
+	}
+	return requestBody.ValueString()
+}
+
+// keyBody returns the request body an idempotency key is derived from. A
+// write-only body replaces body with its hash, so that the key changes with
+// the secret without containing it.
+func (a writeOnlyArguments) keyBody(body string) string {
+	if !a.RequestBody.IsNull() && !a.RequestBody.IsUnknown() {
+		return fmt.Sprintf("wo:sha256:%x", sha256.Sum256([]byte(a.RequestBody.ValueString())))
+	}
+	return body
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWriteOnlyArguments(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	request.Header.Set("Authorization", "from headers")
+
+	arguments := writeOnlyArguments{
+		Headers:     types.MapValueMust(types.StringType, map[string]attr.Value{"Authorization": types.StringValue("from headers_wo")}),
+		RequestBody: types.StringValue("secret"),
+	}
+	arguments.setHeaders(request)
+	if got := request.Header.Get("Authorization"); got != "from headers_wo" {
+		t.Errorf("expected write-only header to take precedence, got %q", got)
+	}
+	if got := string(arguments.body(types.StringValue("public"))); got != "secret" {
+		t.Errorf("expected write-only body, got %q", got)
+	}
+
+	unset := writeOnlyArguments{Headers: types.MapNull(types.StringType), RequestBody: types.StringNull()}
+	unset.setHeaders(request)
+	if got := string(unset.body(types.StringValue("public"))); got != "public" {
+		t.Errorf("expected regular body, got %q", got)
+	}
+}
+
+func TestWriteOnlyKeyBody(t *testing.T) {
+	arguments := writeOnlyArguments{RequestBody: types.StringNull()}
+	if got := arguments.keyBody(`{"a":1}`); got != `{"a":1}` {
+		t.Errorf("expected the body without a write-only body, got %q", got)
+	}
+
+	arguments.RequestBody = types.StringValue("s3cret")
+	first := arguments.keyBody(`{"a":1}`)
+	if strings.Contains(first, "s3cret") {
+		t.Errorf("expected the write-only body to be hashed, got %q", first)
+	}
+	arguments.RequestBody = types.StringValue("rotated")
+	if second := arguments.keyBody(`{"a":1}`); second == first {
+		t.Error("expected a different key body for a different write-only body")
+	}
+}